@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/scheduler-plugins/apis/config"
 	v1 "sigs.k8s.io/scheduler-plugins/apis/config/v1"
 	"sigs.k8s.io/scheduler-plugins/pkg/coscheduling"
+	"sigs.k8s.io/scheduler-plugins/pkg/multiobjective"
 	"sigs.k8s.io/scheduler-plugins/pkg/networkaware/networkoverhead"
 	"sigs.k8s.io/scheduler-plugins/pkg/networkaware/topologicalsort"
 	"sigs.k8s.io/scheduler-plugins/pkg/noderesources"
@@ -147,6 +148,73 @@ profiles:
 				},
 			},
 		},
+		{
+			name: "v1 MultiObjective args with custom hint name prefix",
+			data: []byte(`
+apiVersion: kubescheduler.config.k8s.io/v1
+kind: KubeSchedulerConfiguration
+profiles:
+- schedulerName: scheduler-plugins
+  pluginConfig:
+  - name: MultiObjective
+    args:
+      hintNamePrefix: "tenant-a-hints-"
+      slotUpdateMaxRetries: 5
+`),
+			wantProfiles: []schedconfig.KubeSchedulerProfile{
+				{
+					SchedulerName: "scheduler-plugins",
+					Plugins:       defaults.PluginsV1,
+					PluginConfig: []schedconfig.PluginConfig{
+						{
+							Name: multiobjective.Name,
+							Args: &config.MultiObjectiveArgs{
+								ObjectiveWeights:              []float64{0, 0, 0},
+								SystemNamespaces:              []string{"kube-system", "kube-public", "kube-node-lease", "local-path-storage"},
+								ControlPlaneLabels:            []string{"node-role.kubernetes.io/control-plane"},
+								SlotUpdateMaxRetries:          5,
+								HintNamePrefix:                "tenant-a-hints-",
+								MaxInfluence:                  100,
+								HintLookupTimeoutMilliseconds: 200,
+							},
+						},
+						{
+							Name: "DefaultPreemption",
+							Args: &schedconfig.DefaultPreemptionArgs{MinCandidateNodesPercentage: 10, MinCandidateNodesAbsolute: 100},
+						},
+						{
+							Name: "InterPodAffinity",
+							Args: &schedconfig.InterPodAffinityArgs{HardPodAffinityWeight: 1},
+						},
+						{
+							Name: "NodeAffinity",
+							Args: &schedconfig.NodeAffinityArgs{},
+						},
+						{
+							Name: "NodeResourcesBalancedAllocation",
+							Args: &schedconfig.NodeResourcesBalancedAllocationArgs{Resources: []schedconfig.ResourceSpec{{Name: "cpu", Weight: 1}, {Name: "memory", Weight: 1}}},
+						},
+						{
+							Name: "NodeResourcesFit",
+							Args: &schedconfig.NodeResourcesFitArgs{
+								ScoringStrategy: &schedconfig.ScoringStrategy{
+									Type:      schedconfig.LeastAllocated,
+									Resources: []schedconfig.ResourceSpec{{Name: "cpu", Weight: 1}, {Name: "memory", Weight: 1}},
+								},
+							},
+						},
+						{
+							Name: "PodTopologySpread",
+							Args: &schedconfig.PodTopologySpreadArgs{DefaultingType: schedconfig.SystemDefaulting},
+						},
+						{
+							Name: "VolumeBinding",
+							Args: &schedconfig.VolumeBindingArgs{BindTimeoutSeconds: 600},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "v1 plugin args unspecified to verify the default profile",
 			data: []byte(`
@@ -306,4 +306,70 @@ type MultiObjectiveArgs struct {
 
 	// User preferences for objective functions, stored as weights
 	ObjectiveWeights []float64
+
+	// StrictHint only lets a pod whose ReplicaSet is targeted by a scheduling hint onto the hint's target
+	// nodes with slots left, so the pod stays Unschedulable while those nodes are infeasible or used up
+	// instead of falling back to default scoring
+	StrictHint bool
+
+	// SystemNamespaces are excluded from the cluster fingerprint and from hint-based placement
+	SystemNamespaces []string
+
+	// ControlPlaneLabels are node label keys identifying control-plane nodes, which are excluded
+	// from the cluster fingerprint and never selected as hint targets
+	ControlPlaneLabels []string
+
+	// ControlPlaneTaints are node taints identifying control-plane nodes in addition to ControlPlaneLabels,
+	// each given as a taint key matching any effect or as key:Effect. Like the labels, they must match how the
+	// descheduler detects control-plane nodes, since the nodes are excluded from the cluster fingerprint
+	ControlPlaneTaints []string
+
+	// FilterNonTargetNodes filters out every node without available slots in the scheduling hint's
+	// movement for the pod's ReplicaSet, instead of only preferring the target node at scoring
+	FilterNonTargetNodes bool
+
+	// FilterExhaustedTargetNodes filters out target nodes of the scheduling hint's movement for the pod's
+	// ReplicaSet whose slots are used up while other target nodes still have slots. Nodes the movement
+	// does not target are left to scoring
+	FilterExhaustedTargetNodes bool
+
+	// FingerprintNodeResources includes each node's allocatable CPU and memory in the cluster
+	// fingerprint, so hints only match the resource topology they were computed for
+	FingerprintNodeResources bool
+
+	// FingerprintNodeLabels are node label keys whose values are included in the cluster fingerprint
+	FingerprintNodeLabels []string
+
+	// DryRun only logs the placement decisions derived from scheduling hints, without consuming
+	// slots, filtering nodes or influencing scores
+	DryRun bool
+
+	// SlotUpdateMaxRetries is the maximum number of attempts to consume or release a hint slot,
+	// backing off exponentially with jitter between attempts that conflict with other updates
+	SlotUpdateMaxRetries int64
+
+	// HintNamePrefix is prepended to the cluster fingerprint to name the SchedulingHint to look up.
+	// It must match the prefix used by the descheduler instance writing the hints
+	HintNamePrefix string
+
+	// SlotConfirmationTimeoutSeconds is how long Permit holds a pod whose hint slot was consumed until
+	// the consumption is observed on a re-read of the hint, rejecting the pod if it is not. A value of 0
+	// binds pods without waiting for confirmation
+	SlotConfirmationTimeoutSeconds int64
+
+	// MaxInfluence is the score given to the hint's target node, between 1 and 100. The framework
+	// multiplies it by the plugin's weight and adds it to the other score plugins' weighted scores, so
+	// with equal weights a MaxInfluence of 100 overrides e.g. NodeResourcesFit preferences, while lower
+	// values let strong resource fit preferences outweigh the hint
+	MaxInfluence int64
+
+	// MaxWeightedScore ignores scheduling hint solutions with a higher WeightedScore, so placement is not
+	// perturbed for negligible optimization gains. Hints without any solution within it fall back to default
+	// scoring. Lower weighted scores are better. Unset uses every solution regardless of its score
+	MaxWeightedScore *float64
+
+	// HintLookupTimeoutMilliseconds bounds each scheduling hint lookup, so a slow API server makes the
+	// plugin fall back to default scoring instead of stalling the scheduling cycle. A value of 0 only
+	// bounds lookups by the scheduling cycle's context
+	HintLookupTimeoutMilliseconds int64
 }
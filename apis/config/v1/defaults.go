@@ -102,6 +102,32 @@ var (
 	DefaultSySchedProfileNamespace = "default"
 	// DefaultSySchedProfileName is the name of the default syscall profile CR for SySched plugin
 	DefaultSySchedProfileName = "all-syscalls"
+
+	// Defaults for MultiObjective
+	// DefaultMultiObjectiveStrictHint keeps score-only behavior when no hint target is eligible
+	DefaultMultiObjectiveStrictHint = false
+	// DefaultMultiObjectiveSystemNamespaces matches the namespaces the descheduler excludes from its fingerprint
+	DefaultMultiObjectiveSystemNamespaces = []string{"kube-system", "kube-public", "kube-node-lease", "local-path-storage"}
+	// DefaultMultiObjectiveControlPlaneLabels identifies control-plane nodes by the standard node-role label
+	DefaultMultiObjectiveControlPlaneLabels = []string{"node-role.kubernetes.io/control-plane"}
+	// DefaultMultiObjectiveFilterNonTargetNodes keeps score-only placement
+	DefaultMultiObjectiveFilterNonTargetNodes = false
+	// DefaultMultiObjectiveFilterExhaustedTargetNodes leaves exhausted target nodes to scoring
+	DefaultMultiObjectiveFilterExhaustedTargetNodes = false
+	// DefaultMultiObjectiveFingerprintNodeResources keeps the fingerprint compatible with hints keyed by node names only
+	DefaultMultiObjectiveFingerprintNodeResources = false
+	// DefaultMultiObjectiveDryRun lets scheduling hints steer placement
+	DefaultMultiObjectiveDryRun = false
+	// DefaultMultiObjectiveSlotUpdateMaxRetries gives up on a slot update after three conflicting attempts
+	DefaultMultiObjectiveSlotUpdateMaxRetries int64 = 3
+	// DefaultMultiObjectiveHintNamePrefix matches the descheduler's default hint naming
+	DefaultMultiObjectiveHintNamePrefix = "multiobjective-hints-"
+	// DefaultMultiObjectiveSlotConfirmationTimeoutSeconds binds pods without waiting for slot confirmation
+	DefaultMultiObjectiveSlotConfirmationTimeoutSeconds int64 = 0
+	// DefaultMultiObjectiveMaxInfluence gives the hint's target node the max node score
+	DefaultMultiObjectiveMaxInfluence int64 = 100
+	// DefaultMultiObjectiveHintLookupTimeoutMilliseconds gives up on a hint lookup after 200ms
+	DefaultMultiObjectiveHintLookupTimeoutMilliseconds int64 = 200
 )
 
 // SetDefaults_CoschedulingArgs sets the default parameters for Coscheduling plugin.
@@ -256,4 +282,49 @@ func SetDefaults_MultiObjectiveArgs(obj *MultiObjectiveArgs) {
 	if len(obj.ObjectiveWeights) == 0 {
 		obj.ObjectiveWeights = []float64{0, 0, 0}
 	}
+
+	if obj.StrictHint == nil {
+		obj.StrictHint = &DefaultMultiObjectiveStrictHint
+	}
+
+	if len(obj.SystemNamespaces) == 0 {
+		obj.SystemNamespaces = DefaultMultiObjectiveSystemNamespaces
+	}
+
+	if len(obj.ControlPlaneLabels) == 0 {
+		obj.ControlPlaneLabels = DefaultMultiObjectiveControlPlaneLabels
+	}
+
+	if obj.FilterNonTargetNodes == nil {
+		obj.FilterNonTargetNodes = &DefaultMultiObjectiveFilterNonTargetNodes
+	}
+	if obj.FilterExhaustedTargetNodes == nil {
+		obj.FilterExhaustedTargetNodes = &DefaultMultiObjectiveFilterExhaustedTargetNodes
+	}
+
+	if obj.FingerprintNodeResources == nil {
+		obj.FingerprintNodeResources = &DefaultMultiObjectiveFingerprintNodeResources
+	}
+
+	if obj.DryRun == nil {
+		obj.DryRun = &DefaultMultiObjectiveDryRun
+	}
+
+	if obj.SlotUpdateMaxRetries == nil {
+		obj.SlotUpdateMaxRetries = &DefaultMultiObjectiveSlotUpdateMaxRetries
+	}
+
+	if obj.HintNamePrefix == nil {
+		obj.HintNamePrefix = &DefaultMultiObjectiveHintNamePrefix
+	}
+
+	if obj.SlotConfirmationTimeoutSeconds == nil {
+		obj.SlotConfirmationTimeoutSeconds = &DefaultMultiObjectiveSlotConfirmationTimeoutSeconds
+	}
+	if obj.MaxInfluence == nil {
+		obj.MaxInfluence = &DefaultMultiObjectiveMaxInfluence
+	}
+	if obj.HintLookupTimeoutMilliseconds == nil {
+		obj.HintLookupTimeoutMilliseconds = &DefaultMultiObjectiveHintLookupTimeoutMilliseconds
+	}
 }
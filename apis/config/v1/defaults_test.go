@@ -274,6 +274,64 @@ func TestSchedulingDefaults(t *testing.T) {
 				DefaultProfileName:      pointer.StringPtr("all-syscalls"),
 			},
 		},
+		{
+			name:   "empty config MultiObjectiveArgs",
+			config: &MultiObjectiveArgs{},
+			expect: &MultiObjectiveArgs{
+				ObjectiveWeights:               []float64{0, 0, 0},
+				StrictHint:                     pointer.BoolPtr(false),
+				SystemNamespaces:               []string{"kube-system", "kube-public", "kube-node-lease", "local-path-storage"},
+				ControlPlaneLabels:             []string{"node-role.kubernetes.io/control-plane"},
+				FilterNonTargetNodes:           pointer.BoolPtr(false),
+				FilterExhaustedTargetNodes:     pointer.BoolPtr(false),
+				FingerprintNodeResources:       pointer.BoolPtr(false),
+				DryRun:                         pointer.BoolPtr(false),
+				SlotUpdateMaxRetries:           pointer.Int64Ptr(3),
+				HintNamePrefix:                 pointer.StringPtr("multiobjective-hints-"),
+				SlotConfirmationTimeoutSeconds: pointer.Int64Ptr(0),
+				MaxInfluence:                   pointer.Int64Ptr(100),
+				HintLookupTimeoutMilliseconds:  pointer.Int64Ptr(200),
+			},
+		},
+		{
+			name: "set non default MultiObjectiveArgs",
+			config: &MultiObjectiveArgs{
+				ObjectiveWeights:               []float64{0.5, 0.3, 0.2},
+				StrictHint:                     pointer.BoolPtr(true),
+				SystemNamespaces:               []string{"kube-system", "monitoring"},
+				ControlPlaneLabels:             []string{"node-role.kubernetes.io/master"},
+				ControlPlaneTaints:             []string{"node-role.kubernetes.io/master:NoSchedule"},
+				FilterNonTargetNodes:           pointer.BoolPtr(true),
+				FilterExhaustedTargetNodes:     pointer.BoolPtr(true),
+				FingerprintNodeResources:       pointer.BoolPtr(true),
+				FingerprintNodeLabels:          []string{"topology.kubernetes.io/zone"},
+				DryRun:                         pointer.BoolPtr(true),
+				SlotUpdateMaxRetries:           pointer.Int64Ptr(5),
+				HintNamePrefix:                 pointer.StringPtr("tenant-a-hints-"),
+				SlotConfirmationTimeoutSeconds: pointer.Int64Ptr(10),
+				MaxInfluence:                   pointer.Int64Ptr(50),
+				MaxWeightedScore:               pointer.Float64Ptr(0.4),
+				HintLookupTimeoutMilliseconds:  pointer.Int64Ptr(500),
+			},
+			expect: &MultiObjectiveArgs{
+				ObjectiveWeights:               []float64{0.5, 0.3, 0.2},
+				StrictHint:                     pointer.BoolPtr(true),
+				SystemNamespaces:               []string{"kube-system", "monitoring"},
+				ControlPlaneLabels:             []string{"node-role.kubernetes.io/master"},
+				ControlPlaneTaints:             []string{"node-role.kubernetes.io/master:NoSchedule"},
+				FilterNonTargetNodes:           pointer.BoolPtr(true),
+				FilterExhaustedTargetNodes:     pointer.BoolPtr(true),
+				FingerprintNodeResources:       pointer.BoolPtr(true),
+				FingerprintNodeLabels:          []string{"topology.kubernetes.io/zone"},
+				DryRun:                         pointer.BoolPtr(true),
+				SlotUpdateMaxRetries:           pointer.Int64Ptr(5),
+				HintNamePrefix:                 pointer.StringPtr("tenant-a-hints-"),
+				SlotConfirmationTimeoutSeconds: pointer.Int64Ptr(10),
+				MaxInfluence:                   pointer.Int64Ptr(50),
+				MaxWeightedScore:               pointer.Float64Ptr(0.4),
+				HintLookupTimeoutMilliseconds:  pointer.Int64Ptr(500),
+			},
+		},
 	}
 
 	for _, tc := range tests {
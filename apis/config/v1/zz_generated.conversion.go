@@ -351,6 +351,41 @@ func Convert_config_MetricProviderSpec_To_v1_MetricProviderSpec(in *config.Metri
 
 func autoConvert_v1_MultiObjectiveArgs_To_config_MultiObjectiveArgs(in *MultiObjectiveArgs, out *config.MultiObjectiveArgs, s conversion.Scope) error {
 	out.ObjectiveWeights = *(*[]float64)(unsafe.Pointer(&in.ObjectiveWeights))
+	if err := metav1.Convert_Pointer_bool_To_bool(&in.StrictHint, &out.StrictHint, s); err != nil {
+		return err
+	}
+	out.SystemNamespaces = *(*[]string)(unsafe.Pointer(&in.SystemNamespaces))
+	out.ControlPlaneLabels = *(*[]string)(unsafe.Pointer(&in.ControlPlaneLabels))
+	out.ControlPlaneTaints = *(*[]string)(unsafe.Pointer(&in.ControlPlaneTaints))
+	if err := metav1.Convert_Pointer_bool_To_bool(&in.FilterNonTargetNodes, &out.FilterNonTargetNodes, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_bool_To_bool(&in.FilterExhaustedTargetNodes, &out.FilterExhaustedTargetNodes, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_bool_To_bool(&in.FingerprintNodeResources, &out.FingerprintNodeResources, s); err != nil {
+		return err
+	}
+	out.FingerprintNodeLabels = *(*[]string)(unsafe.Pointer(&in.FingerprintNodeLabels))
+	if err := metav1.Convert_Pointer_bool_To_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.SlotUpdateMaxRetries, &out.SlotUpdateMaxRetries, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_string_To_string(&in.HintNamePrefix, &out.HintNamePrefix, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.SlotConfirmationTimeoutSeconds, &out.SlotConfirmationTimeoutSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.MaxInfluence, &out.MaxInfluence, s); err != nil {
+		return err
+	}
+	out.MaxWeightedScore = (*float64)(unsafe.Pointer(in.MaxWeightedScore))
+	if err := metav1.Convert_Pointer_int64_To_int64(&in.HintLookupTimeoutMilliseconds, &out.HintLookupTimeoutMilliseconds, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -361,6 +396,41 @@ func Convert_v1_MultiObjectiveArgs_To_config_MultiObjectiveArgs(in *MultiObjecti
 
 func autoConvert_config_MultiObjectiveArgs_To_v1_MultiObjectiveArgs(in *config.MultiObjectiveArgs, out *MultiObjectiveArgs, s conversion.Scope) error {
 	out.ObjectiveWeights = *(*[]float64)(unsafe.Pointer(&in.ObjectiveWeights))
+	if err := metav1.Convert_bool_To_Pointer_bool(&in.StrictHint, &out.StrictHint, s); err != nil {
+		return err
+	}
+	out.SystemNamespaces = *(*[]string)(unsafe.Pointer(&in.SystemNamespaces))
+	out.ControlPlaneLabels = *(*[]string)(unsafe.Pointer(&in.ControlPlaneLabels))
+	out.ControlPlaneTaints = *(*[]string)(unsafe.Pointer(&in.ControlPlaneTaints))
+	if err := metav1.Convert_bool_To_Pointer_bool(&in.FilterNonTargetNodes, &out.FilterNonTargetNodes, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_bool_To_Pointer_bool(&in.FilterExhaustedTargetNodes, &out.FilterExhaustedTargetNodes, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_bool_To_Pointer_bool(&in.FingerprintNodeResources, &out.FingerprintNodeResources, s); err != nil {
+		return err
+	}
+	out.FingerprintNodeLabels = *(*[]string)(unsafe.Pointer(&in.FingerprintNodeLabels))
+	if err := metav1.Convert_bool_To_Pointer_bool(&in.DryRun, &out.DryRun, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.SlotUpdateMaxRetries, &out.SlotUpdateMaxRetries, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_string_To_Pointer_string(&in.HintNamePrefix, &out.HintNamePrefix, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.SlotConfirmationTimeoutSeconds, &out.SlotConfirmationTimeoutSeconds, s); err != nil {
+		return err
+	}
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.MaxInfluence, &out.MaxInfluence, s); err != nil {
+		return err
+	}
+	out.MaxWeightedScore = (*float64)(unsafe.Pointer(in.MaxWeightedScore))
+	if err := metav1.Convert_int64_To_Pointer_int64(&in.HintLookupTimeoutMilliseconds, &out.HintLookupTimeoutMilliseconds, s); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -176,6 +176,81 @@ func (in *MultiObjectiveArgs) DeepCopyInto(out *MultiObjectiveArgs) {
 		*out = make([]float64, len(*in))
 		copy(*out, *in)
 	}
+	if in.StrictHint != nil {
+		in, out := &in.StrictHint, &out.StrictHint
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SystemNamespaces != nil {
+		in, out := &in.SystemNamespaces, &out.SystemNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControlPlaneLabels != nil {
+		in, out := &in.ControlPlaneLabels, &out.ControlPlaneLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControlPlaneTaints != nil {
+		in, out := &in.ControlPlaneTaints, &out.ControlPlaneTaints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FilterNonTargetNodes != nil {
+		in, out := &in.FilterNonTargetNodes, &out.FilterNonTargetNodes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FilterExhaustedTargetNodes != nil {
+		in, out := &in.FilterExhaustedTargetNodes, &out.FilterExhaustedTargetNodes
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FingerprintNodeResources != nil {
+		in, out := &in.FingerprintNodeResources, &out.FingerprintNodeResources
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FingerprintNodeLabels != nil {
+		in, out := &in.FingerprintNodeLabels, &out.FingerprintNodeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DryRun != nil {
+		in, out := &in.DryRun, &out.DryRun
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SlotUpdateMaxRetries != nil {
+		in, out := &in.SlotUpdateMaxRetries, &out.SlotUpdateMaxRetries
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HintNamePrefix != nil {
+		in, out := &in.HintNamePrefix, &out.HintNamePrefix
+		*out = new(string)
+		**out = **in
+	}
+	if in.SlotConfirmationTimeoutSeconds != nil {
+		in, out := &in.SlotConfirmationTimeoutSeconds, &out.SlotConfirmationTimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxInfluence != nil {
+		in, out := &in.MaxInfluence, &out.MaxInfluence
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MaxWeightedScore != nil {
+		in, out := &in.MaxWeightedScore, &out.MaxWeightedScore
+		*out = new(float64)
+		**out = **in
+	}
+	if in.HintLookupTimeoutMilliseconds != nil {
+		in, out := &in.HintLookupTimeoutMilliseconds, &out.HintLookupTimeoutMilliseconds
+		*out = new(int64)
+		**out = **in
+	}
 	return
 }
 
@@ -136,6 +136,31 @@ func (in *MultiObjectiveArgs) DeepCopyInto(out *MultiObjectiveArgs) {
 		*out = make([]float64, len(*in))
 		copy(*out, *in)
 	}
+	if in.SystemNamespaces != nil {
+		in, out := &in.SystemNamespaces, &out.SystemNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControlPlaneLabels != nil {
+		in, out := &in.ControlPlaneLabels, &out.ControlPlaneLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ControlPlaneTaints != nil {
+		in, out := &in.ControlPlaneTaints, &out.ControlPlaneTaints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FingerprintNodeLabels != nil {
+		in, out := &in.FingerprintNodeLabels, &out.FingerprintNodeLabels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxWeightedScore != nil {
+		in, out := &in.MaxWeightedScore, &out.MaxWeightedScore
+		*out = new(float64)
+		**out = **in
+	}
 	return
 }
 
@@ -0,0 +1,51 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// SchedulingHintConditionReady indicates the hint's solutions are complete and can be consumed
+	SchedulingHintConditionReady = "Ready"
+
+	// SchedulingHintConditionStale indicates the cluster changed since the hint's solutions were generated
+	SchedulingHintConditionStale = "Stale"
+)
+
+// SetCondition adds the condition to the hint's status or updates the existing condition of the same type,
+// only moving LastTransitionTime when the condition's status changes
+func (h *SchedulingHint) SetCondition(cond metav1.Condition) {
+	meta.SetStatusCondition(&h.Status.Conditions, cond)
+}
+
+// GetCondition returns the hint's condition of the given type, or nil if it is not set
+func (h *SchedulingHint) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(h.Status.Conditions, conditionType)
+}
+
+// IsConditionTrue reports whether the hint's condition of the given type is set and has status True
+func (h *SchedulingHint) IsConditionTrue(conditionType string) bool {
+	return meta.IsStatusConditionTrue(h.Status.Conditions, conditionType)
+}
+
+// RemoveCondition removes the hint's condition of the given type, if present
+func (h *SchedulingHint) RemoveCondition(conditionType string) {
+	meta.RemoveStatusCondition(&h.Status.Conditions, conditionType)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSchedulingHintConditions(t *testing.T) {
+	created := metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	later := metav1.NewTime(created.Add(time.Minute))
+
+	hint := &SchedulingHint{}
+	if got := hint.GetCondition(SchedulingHintConditionReady); got != nil {
+		t.Fatalf("GetCondition() on empty status = %v, want nil", got)
+	}
+	if hint.IsConditionTrue(SchedulingHintConditionReady) {
+		t.Fatalf("IsConditionTrue() on empty status = true, want false")
+	}
+
+	// Setting a new condition adds it
+	hint.SetCondition(metav1.Condition{
+		Type:               SchedulingHintConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "SolutionsGenerated",
+		LastTransitionTime: created,
+	})
+	hint.SetCondition(metav1.Condition{
+		Type:               SchedulingHintConditionStale,
+		Status:             metav1.ConditionFalse,
+		Reason:             "FingerprintMatches",
+		LastTransitionTime: created,
+	})
+	if len(hint.Status.Conditions) != 2 {
+		t.Fatalf("got %d conditions, want 2", len(hint.Status.Conditions))
+	}
+	if !hint.IsConditionTrue(SchedulingHintConditionReady) {
+		t.Errorf("IsConditionTrue(Ready) = false, want true")
+	}
+	if hint.IsConditionTrue(SchedulingHintConditionStale) {
+		t.Errorf("IsConditionTrue(Stale) = true, want false")
+	}
+
+	// Updating without a status change keeps the transition time
+	hint.SetCondition(metav1.Condition{
+		Type:               SchedulingHintConditionReady,
+		Status:             metav1.ConditionTrue,
+		Reason:             "SolutionsRefreshed",
+		LastTransitionTime: later,
+	})
+	ready := hint.GetCondition(SchedulingHintConditionReady)
+	if ready == nil {
+		t.Fatalf("GetCondition(Ready) = nil after update")
+	}
+	if ready.Reason != "SolutionsRefreshed" {
+		t.Errorf("Ready reason = %q, want %q", ready.Reason, "SolutionsRefreshed")
+	}
+	if !ready.LastTransitionTime.Equal(&created) {
+		t.Errorf("Ready LastTransitionTime = %v, want %v", ready.LastTransitionTime, created)
+	}
+
+	// A status change updates the transition time
+	hint.SetCondition(metav1.Condition{
+		Type:               SchedulingHintConditionStale,
+		Status:             metav1.ConditionTrue,
+		Reason:             "NodesRemoved",
+		LastTransitionTime: later,
+	})
+	stale := hint.GetCondition(SchedulingHintConditionStale)
+	if stale == nil || stale.Status != metav1.ConditionTrue {
+		t.Fatalf("GetCondition(Stale) = %v, want status True", stale)
+	}
+	if !stale.LastTransitionTime.Equal(&later) {
+		t.Errorf("Stale LastTransitionTime = %v, want %v", stale.LastTransitionTime, later)
+	}
+	if len(hint.Status.Conditions) != 2 {
+		t.Errorf("got %d conditions after updates, want 2", len(hint.Status.Conditions))
+	}
+
+	hint.RemoveCondition(SchedulingHintConditionStale)
+	if got := hint.GetCondition(SchedulingHintConditionStale); got != nil {
+		t.Errorf("GetCondition(Stale) after removal = %v, want nil", got)
+	}
+	if hint.GetCondition(SchedulingHintConditionReady) == nil {
+		t.Errorf("RemoveCondition(Stale) also removed Ready")
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"sigs.k8s.io/scheduler-plugins/apis/descheduler/v1beta1"
+)
+
+var _ conversion.Convertible = &SchedulingHint{}
+
+// ConvertTo converts this SchedulingHint to the hub version (v1beta1). The source is deep-copied first so
+// that the converted hint shares no maps or slices with it.
+func (src *SchedulingHint) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1beta1.SchedulingHint)
+	src = src.DeepCopy()
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.ClusterFingerprint = src.Spec.ClusterFingerprint
+	dst.Spec.ClusterNodes = src.Spec.ClusterNodes
+	dst.Spec.ExpirationTime = src.Spec.ExpirationTime
+	dst.Spec.GeneratedAt = src.Spec.GeneratedAt
+	dst.Spec.DeschedulerVersion = src.Spec.DeschedulerVersion
+	dst.Spec.Seed = src.Spec.Seed
+	dst.Spec.ObjectiveWeights = nil
+	if src.Spec.ObjectiveWeights != nil {
+		dst.Spec.ObjectiveWeights = &v1beta1.ObjectiveWeights{
+			Cost:       src.Spec.ObjectiveWeights.Cost,
+			Disruption: src.Spec.ObjectiveWeights.Disruption,
+			Balance:    src.Spec.ObjectiveWeights.Balance,
+		}
+	}
+
+	dst.Spec.OriginalReplicaSetDistribution = nil
+	if src.Spec.OriginalReplicaSetDistribution != nil {
+		dst.Spec.OriginalReplicaSetDistribution = make([]v1beta1.ReplicaSetDistribution, len(src.Spec.OriginalReplicaSetDistribution))
+		for i, d := range src.Spec.OriginalReplicaSetDistribution {
+			dst.Spec.OriginalReplicaSetDistribution[i] = v1beta1.ReplicaSetDistribution{
+				Namespace:        d.Namespace,
+				ReplicaSetName:   d.ReplicaSetName,
+				NodeDistribution: d.NodeDistribution,
+			}
+		}
+	}
+
+	dst.Spec.Solutions = nil
+	if src.Spec.Solutions != nil {
+		dst.Spec.Solutions = make([]v1beta1.OptimizationSolution, len(src.Spec.Solutions))
+		for i, sol := range src.Spec.Solutions {
+			dst.Spec.Solutions[i] = v1beta1.OptimizationSolution{
+				Rank:          sol.Rank,
+				WeightedScore: sol.WeightedScore,
+				Objectives: v1beta1.ObjectiveValues{
+					Cost:       sol.Objectives.Cost,
+					Disruption: sol.Objectives.Disruption,
+					Balance:    sol.Objectives.Balance,
+				},
+				NamedObjectives: sol.NamedObjectives,
+				MovementCount:   sol.MovementCount,
+			}
+			if sol.ReplicaSetMovements != nil {
+				movements := make([]v1beta1.ReplicaSetMovement, len(sol.ReplicaSetMovements))
+				for j, m := range sol.ReplicaSetMovements {
+					movements[j] = v1beta1.ReplicaSetMovement{
+						ReplicaSetName:     m.ReplicaSetName,
+						Namespace:          m.Namespace,
+						TargetDistribution: m.TargetDistribution,
+						AvailableSlots:     m.AvailableSlots,
+						ScheduledCount:     m.ScheduledCount,
+						Reason:             m.Reason,
+					}
+				}
+				dst.Spec.Solutions[i].ReplicaSetMovements = movements
+			}
+		}
+	}
+
+	dst.Status = v1beta1.SchedulingHintStatus{
+		Phase:            v1beta1.SchedulingHintPhase(src.Status.Phase),
+		AppliedMovements: src.Status.AppliedMovements,
+		RemainingSlots:   src.Status.RemainingSlots,
+		LastAppliedTime:  src.Status.LastAppliedTime,
+		Conditions:       src.Status.Conditions,
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1beta1) to this version. The source is deep-copied first so
+// that the converted hint shares no maps or slices with it.
+func (dst *SchedulingHint) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1beta1.SchedulingHint).DeepCopy()
+	dst.ObjectMeta = src.ObjectMeta
+
+	dst.Spec.ClusterFingerprint = src.Spec.ClusterFingerprint
+	dst.Spec.ClusterNodes = src.Spec.ClusterNodes
+	dst.Spec.ExpirationTime = src.Spec.ExpirationTime
+	dst.Spec.GeneratedAt = src.Spec.GeneratedAt
+	dst.Spec.DeschedulerVersion = src.Spec.DeschedulerVersion
+	dst.Spec.Seed = src.Spec.Seed
+	dst.Spec.ObjectiveWeights = nil
+	if src.Spec.ObjectiveWeights != nil {
+		dst.Spec.ObjectiveWeights = &ObjectiveWeights{
+			Cost:       src.Spec.ObjectiveWeights.Cost,
+			Disruption: src.Spec.ObjectiveWeights.Disruption,
+			Balance:    src.Spec.ObjectiveWeights.Balance,
+		}
+	}
+
+	dst.Spec.OriginalReplicaSetDistribution = nil
+	if src.Spec.OriginalReplicaSetDistribution != nil {
+		dst.Spec.OriginalReplicaSetDistribution = make([]ReplicaSetDistribution, len(src.Spec.OriginalReplicaSetDistribution))
+		for i, d := range src.Spec.OriginalReplicaSetDistribution {
+			dst.Spec.OriginalReplicaSetDistribution[i] = ReplicaSetDistribution{
+				Namespace:        d.Namespace,
+				ReplicaSetName:   d.ReplicaSetName,
+				NodeDistribution: d.NodeDistribution,
+			}
+		}
+	}
+
+	dst.Spec.Solutions = nil
+	if src.Spec.Solutions != nil {
+		dst.Spec.Solutions = make([]OptimizationSolution, len(src.Spec.Solutions))
+		for i, sol := range src.Spec.Solutions {
+			dst.Spec.Solutions[i] = OptimizationSolution{
+				Rank:          sol.Rank,
+				WeightedScore: sol.WeightedScore,
+				Objectives: ObjectiveValues{
+					Cost:       sol.Objectives.Cost,
+					Disruption: sol.Objectives.Disruption,
+					Balance:    sol.Objectives.Balance,
+				},
+				NamedObjectives: sol.NamedObjectives,
+				MovementCount:   sol.MovementCount,
+			}
+			if sol.ReplicaSetMovements != nil {
+				movements := make([]ReplicaSetMovement, len(sol.ReplicaSetMovements))
+				for j, m := range sol.ReplicaSetMovements {
+					movements[j] = ReplicaSetMovement{
+						ReplicaSetName:     m.ReplicaSetName,
+						Namespace:          m.Namespace,
+						TargetDistribution: m.TargetDistribution,
+						AvailableSlots:     m.AvailableSlots,
+						ScheduledCount:     m.ScheduledCount,
+						Reason:             m.Reason,
+					}
+				}
+				dst.Spec.Solutions[i].ReplicaSetMovements = movements
+			}
+		}
+	}
+
+	dst.Status = SchedulingHintStatus{
+		Phase:            SchedulingHintPhase(src.Status.Phase),
+		AppliedMovements: src.Status.AppliedMovements,
+		RemainingSlots:   src.Status.RemainingSlots,
+		LastAppliedTime:  src.Status.LastAppliedTime,
+		Conditions:       src.Status.Conditions,
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/scheduler-plugins/apis/descheduler/v1beta1"
+)
+
+func TestSchedulingHintConversionRoundTrip(t *testing.T) {
+	now := metav1.NewTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	expiration := metav1.NewTime(now.Add(time.Hour))
+
+	tests := []struct {
+		name string
+		hint *SchedulingHint
+	}{
+		{
+			name: "empty hint",
+			hint: &SchedulingHint{
+				ObjectMeta: metav1.ObjectMeta{Name: "multiobjective-hints-empty"},
+			},
+		},
+		{
+			name: "hint with solutions and slot maps",
+			hint: &SchedulingHint{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:            "multiobjective-hints-abc",
+					ResourceVersion: "42",
+					Labels:          map[string]string{"app": "descheduler"},
+				},
+				Spec: SchedulingHintSpec{
+					ClusterFingerprint: "abc",
+					ClusterNodes:       []string{"node-a", "node-b"},
+					OriginalReplicaSetDistribution: []ReplicaSetDistribution{
+						{
+							Namespace:        "default",
+							ReplicaSetName:   "web",
+							NodeDistribution: map[string]int{"node-a": 3},
+						},
+					},
+					Solutions: []OptimizationSolution{
+						{
+							Rank:          1,
+							WeightedScore: 0.25,
+							Objectives:    ObjectiveValues{Cost: 0.1, Disruption: 0.2, Balance: 0.3},
+							NamedObjectives: map[string]float64{
+								"cost": 0.1, "disruption": 0.2, "balance": 0.3, "power": 0.4, "carbon": 0.5,
+							},
+							MovementCount: 1,
+							ReplicaSetMovements: []ReplicaSetMovement{
+								{
+									ReplicaSetName:     "web",
+									Namespace:          "default",
+									TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+									AvailableSlots:     map[string]int{"node-a": 0, "node-b": 1},
+									ScheduledCount:     map[string]int{"node-a": 2},
+									Reason:             "balance",
+								},
+							},
+						},
+						{
+							Rank:          2,
+							WeightedScore: 0.5,
+						},
+					},
+					ExpirationTime:     &expiration,
+					GeneratedAt:        &now,
+					DeschedulerVersion: "v0.1.0",
+					ObjectiveWeights:   &ObjectiveWeights{Cost: 0.6, Disruption: 0.3, Balance: 0.1},
+					Seed:               ptr.To[int64](7),
+				},
+				Status: SchedulingHintStatus{
+					Phase:            SchedulingHintPhaseActive,
+					AppliedMovements: 2,
+					RemainingSlots:   1,
+					LastAppliedTime:  &now,
+					Conditions: []metav1.Condition{
+						{Type: "Ready", Status: metav1.ConditionTrue, Reason: "Generated", LastTransitionTime: now},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hub := &v1beta1.SchedulingHint{}
+			if err := tt.hint.DeepCopy().ConvertTo(hub); err != nil {
+				t.Fatalf("ConvertTo() unexpected error: %v", err)
+			}
+			got := &SchedulingHint{}
+			if err := got.ConvertFrom(hub); err != nil {
+				t.Fatalf("ConvertFrom() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.hint, got); diff != "" {
+				t.Errorf("unexpected hint after round trip (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSchedulingHintConvertFromHub(t *testing.T) {
+	hub := &v1beta1.SchedulingHint{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiobjective-hints-abc"},
+		Spec: v1beta1.SchedulingHintSpec{
+			ClusterFingerprint: "abc",
+			ObjectiveWeights:   &v1beta1.ObjectiveWeights{Cost: 0.5, Disruption: 0.3, Balance: 0.2},
+			Solutions: []v1beta1.OptimizationSolution{
+				{
+					Rank: 1,
+					ReplicaSetMovements: []v1beta1.ReplicaSetMovement{
+						{
+							ReplicaSetName: "web",
+							Namespace:      "default",
+							AvailableSlots: map[string]int{"node-a": 1},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	spoke := &SchedulingHint{}
+	if err := spoke.ConvertFrom(hub); err != nil {
+		t.Fatalf("ConvertFrom() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]int{"node-a": 1}, spoke.Spec.Solutions[0].ReplicaSetMovements[0].AvailableSlots); diff != "" {
+		t.Errorf("unexpected available slots (-want, +got):\n%s", diff)
+	}
+
+	if diff := cmp.Diff(&ObjectiveWeights{Cost: 0.5, Disruption: 0.3, Balance: 0.2}, spoke.Spec.ObjectiveWeights); diff != "" {
+		t.Errorf("unexpected objective weights (-want, +got):\n%s", diff)
+	}
+
+	got := &v1beta1.SchedulingHint{}
+	if err := spoke.ConvertTo(got); err != nil {
+		t.Fatalf("ConvertTo() unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(hub, got); diff != "" {
+		t.Errorf("unexpected hub after round trip (-want, +got):\n%s", diff)
+	}
+
+	// The converted hint must not share slot maps with the hub
+	spoke.Spec.Solutions[0].ReplicaSetMovements[0].AvailableSlots["node-a"] = 0
+	if got := hub.Spec.Solutions[0].ReplicaSetMovements[0].AvailableSlots["node-a"]; got != 1 {
+		t.Errorf("hub available slots changed through the converted hint: got %d, want 1", got)
+	}
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import "sort"
+
+// TotalAvailableSlots returns the number of available slots summed across all of the solution's movements
+func (sol *OptimizationSolution) TotalAvailableSlots() int {
+	total := 0
+	for _, movement := range sol.ReplicaSetMovements {
+		for _, slots := range movement.AvailableSlots {
+			if slots > 0 {
+				total += slots
+			}
+		}
+	}
+	return total
+}
+
+// Names of the objectives covered by ObjectiveValues
+const (
+	ObjectiveCost       = "cost"
+	ObjectiveDisruption = "disruption"
+	ObjectiveBalance    = "balance"
+)
+
+// ObjectiveValue returns the value of the named objective, preferring NamedObjectives over Objectives
+func (sol *OptimizationSolution) ObjectiveValue(name string) (float64, bool) {
+	if value, ok := sol.NamedObjectives[name]; ok {
+		return value, true
+	}
+	switch name {
+	case ObjectiveCost:
+		return sol.Objectives.Cost, true
+	case ObjectiveDisruption:
+		return sol.Objectives.Disruption, true
+	case ObjectiveBalance:
+		return sol.Objectives.Balance, true
+	}
+	return 0, false
+}
+
+// AllObjectives returns the values of all of the solution's objectives by name
+func (sol *OptimizationSolution) AllObjectives() map[string]float64 {
+	objectives := map[string]float64{
+		ObjectiveCost:       sol.Objectives.Cost,
+		ObjectiveDisruption: sol.Objectives.Disruption,
+		ObjectiveBalance:    sol.Objectives.Balance,
+	}
+	for name, value := range sol.NamedObjectives {
+		objectives[name] = value
+	}
+	return objectives
+}
+
+// ComputeWeightedScore returns the weighted sum of the solution's objectives under the given weights,
+// normalized to sum to 1. Lower is better, as all objectives are minimized. Weights without a positive
+// sum give a score of 0
+func (sol *OptimizationSolution) ComputeWeightedScore(weights ObjectiveWeights) float64 {
+	return sol.ComputeNamedWeightedScore(map[string]float64{
+		ObjectiveCost:       weights.Cost,
+		ObjectiveDisruption: weights.Disruption,
+		ObjectiveBalance:    weights.Balance,
+	})
+}
+
+// ComputeNamedWeightedScore is ComputeWeightedScore for weights given by objective name. Objectives
+// the solution has no value for count as 0. The objectives are weighted as they are, so comparing
+// solutions on objectives of different scales should use SchedulingHint.ComputeNamedWeightedScores
+func (sol *OptimizationSolution) ComputeNamedWeightedScore(weights map[string]float64) float64 {
+	total, score := 0.0, 0.0
+	for _, name := range sortedObjectiveNames(weights) {
+		weight := weights[name]
+		total += weight
+		value, _ := sol.ObjectiveValue(name)
+		score += weight * value
+	}
+	if total <= 0 {
+		return 0
+	}
+	return score / total
+}
+
+// ComputeNamedWeightedScores returns the weighted scores of the hint's solutions at the given indexes,
+// in the same order, for weights given by objective name. Each objective is normalized to [0,1] across
+// those solutions before it is weighted, so objectives of different scales count by their weight alone.
+// An objective with the same value in every solution normalizes to 0, and objectives a solution has no
+// value for count as 0 before normalization. Weights without a positive sum give scores of 0
+func (h *SchedulingHint) ComputeNamedWeightedScores(weights map[string]float64, indexes []int) []float64 {
+	scores := make([]float64, len(indexes))
+	total := 0.0
+	for _, weight := range weights {
+		total += weight
+	}
+	if total <= 0 {
+		return scores
+	}
+
+	values := make([]float64, len(indexes))
+	for _, name := range sortedObjectiveNames(weights) {
+		weight := weights[name]
+		low, high := 0.0, 0.0
+		for j, i := range indexes {
+			values[j], _ = h.Spec.Solutions[i].ObjectiveValue(name)
+			if j == 0 || values[j] < low {
+				low = values[j]
+			}
+			if j == 0 || values[j] > high {
+				high = values[j]
+			}
+		}
+		if high == low {
+			continue
+		}
+		for j := range indexes {
+			scores[j] += weight * (values[j] - low) / (high - low)
+		}
+	}
+	for j := range scores {
+		scores[j] /= total
+	}
+	return scores
+}
+
+// sortedObjectiveNames returns the objective names of the weights in sorted order, so weighted sums
+// add up in the same order every time
+func sortedObjectiveNames(weights map[string]float64) []string {
+	names := make([]string, 0, len(weights))
+	for name := range weights {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TargetCountFor returns the number of replicas the movement targets on the node, or 0 if it has none
+func (m *ReplicaSetMovement) TargetCountFor(nodeName string) int {
+	if m == nil {
+		return 0
+	}
+	return m.TargetDistribution[nodeName]
+}
+
+// AvailableSlotsFor returns the movement's available slots on the node, or 0 if it has none
+func (m *ReplicaSetMovement) AvailableSlotsFor(nodeName string) int {
+	if m == nil {
+		return 0
+	}
+	return m.AvailableSlots[nodeName]
+}
+
+// ScheduledCountFor returns the number of pods scheduled into the movement's slots on the node
+func (m *ReplicaSetMovement) ScheduledCountFor(nodeName string) int {
+	if m == nil {
+		return 0
+	}
+	return m.ScheduledCount[nodeName]
+}
+
+// SetSlots sets the movement's available slots and scheduled count on the node, initializing
+// the slot maps of a partially populated movement
+func (m *ReplicaSetMovement) SetSlots(nodeName string, availableSlots, scheduledCount int) {
+	if m.AvailableSlots == nil {
+		m.AvailableSlots = make(map[string]int)
+	}
+	if m.ScheduledCount == nil {
+		m.ScheduledCount = make(map[string]int)
+	}
+	m.AvailableSlots[nodeName] = availableSlots
+	m.ScheduledCount[nodeName] = scheduledCount
+}
+
+// UpdateRemainingSlots recomputes Status.RemainingSlots from the top solution's available slots
+func (h *SchedulingHint) UpdateRemainingSlots() {
+	h.Status.RemainingSlots = 0
+	if len(h.Spec.Solutions) > 0 {
+		h.Status.RemainingSlots = h.Spec.Solutions[0].TotalAvailableSlots()
+	}
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+)
+
+func TestTotalAvailableSlots(t *testing.T) {
+	tests := []struct {
+		name     string
+		solution OptimizationSolution
+		want     int
+	}{
+		{
+			name:     "no movements",
+			solution: OptimizationSolution{Rank: 1},
+			want:     0,
+		},
+		{
+			name: "movement without slots",
+			solution: OptimizationSolution{
+				Rank:                1,
+				ReplicaSetMovements: []ReplicaSetMovement{{ReplicaSetName: "web", Namespace: "default"}},
+			},
+			want: 0,
+		},
+		{
+			name: "slots summed across movements and nodes",
+			solution: OptimizationSolution{
+				Rank: 1,
+				ReplicaSetMovements: []ReplicaSetMovement{
+					{ReplicaSetName: "web", Namespace: "default", AvailableSlots: map[string]int{"node-a": 2, "node-b": 1}},
+					{ReplicaSetName: "api", Namespace: "default", AvailableSlots: map[string]int{"node-a": 0, "node-c": 3}},
+				},
+			},
+			want: 6,
+		},
+		{
+			name: "negative slots are ignored",
+			solution: OptimizationSolution{
+				Rank: 1,
+				ReplicaSetMovements: []ReplicaSetMovement{
+					{ReplicaSetName: "web", Namespace: "default", AvailableSlots: map[string]int{"node-a": 2, "node-b": -1}},
+				},
+			},
+			want: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.solution.TotalAvailableSlots(); got != tt.want {
+				t.Errorf("TotalAvailableSlots() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeWeightedScore(t *testing.T) {
+	solution := OptimizationSolution{
+		Rank:       1,
+		Objectives: ObjectiveValues{Cost: 0.2, Disruption: 0.6, Balance: 0.4},
+	}
+
+	tests := []struct {
+		name    string
+		weights ObjectiveWeights
+		want    float64
+	}{
+		{
+			name:    "single objective",
+			weights: ObjectiveWeights{Cost: 1},
+			want:    0.2,
+		},
+		{
+			name:    "equal weights average the objectives",
+			weights: ObjectiveWeights{Cost: 1, Disruption: 1, Balance: 1},
+			want:    0.4,
+		},
+		{
+			name:    "normalized weights",
+			weights: ObjectiveWeights{Cost: 0.5, Disruption: 0.25, Balance: 0.25},
+			want:    0.35,
+		},
+		{
+			name:    "unnormalized weights match their normalized form",
+			weights: ObjectiveWeights{Cost: 2, Disruption: 1, Balance: 1},
+			want:    0.35,
+		},
+		{
+			name:    "zero weights",
+			weights: ObjectiveWeights{},
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := solution.ComputeWeightedScore(tt.weights); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ComputeWeightedScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestObjectiveValue(t *testing.T) {
+	solution := OptimizationSolution{
+		Rank:            1,
+		Objectives:      ObjectiveValues{Cost: 0.2, Disruption: 0.6, Balance: 0.4},
+		NamedObjectives: map[string]float64{ObjectiveBalance: 0.1, "power": 0.8},
+	}
+
+	tests := []struct {
+		name      string
+		objective string
+		want      float64
+		wantOK    bool
+	}{
+		{
+			name:      "typed objective",
+			objective: ObjectiveCost,
+			want:      0.2,
+			wantOK:    true,
+		},
+		{
+			name:      "named objective overrides typed objective",
+			objective: ObjectiveBalance,
+			want:      0.1,
+			wantOK:    true,
+		},
+		{
+			name:      "named objective",
+			objective: "power",
+			want:      0.8,
+			wantOK:    true,
+		},
+		{
+			name:      "missing objective",
+			objective: "carbon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := solution.ObjectiveValue(tt.objective)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ObjectiveValue(%q) = (%v, %v), want (%v, %v)", tt.objective, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestComputeNamedWeightedScore(t *testing.T) {
+	solution := OptimizationSolution{
+		Rank:            1,
+		Objectives:      ObjectiveValues{Cost: 0.2, Disruption: 0.6, Balance: 0.4},
+		NamedObjectives: map[string]float64{"power": 0.8, "carbon": 0.5},
+	}
+
+	tests := []struct {
+		name    string
+		weights map[string]float64
+		want    float64
+	}{
+		{
+			name:    "typed and named objectives",
+			weights: map[string]float64{ObjectiveCost: 1, "power": 1},
+			want:    0.5,
+		},
+		{
+			name:    "five objectives",
+			weights: map[string]float64{ObjectiveCost: 1, ObjectiveDisruption: 1, ObjectiveBalance: 1, "power": 1, "carbon": 1},
+			want:    0.5,
+		},
+		{
+			name:    "missing objective counts as zero",
+			weights: map[string]float64{"power": 1, "latency": 1},
+			want:    0.4,
+		},
+		{
+			name:    "zero weights",
+			weights: map[string]float64{"power": 0},
+			want:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := solution.ComputeNamedWeightedScore(tt.weights); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("ComputeNamedWeightedScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestComputeNamedWeightedScores(t *testing.T) {
+	hint := SchedulingHint{
+		Spec: SchedulingHintSpec{
+			Solutions: []OptimizationSolution{
+				{Rank: 1, Objectives: ObjectiveValues{Cost: 10, Disruption: 0.9}},
+				{Rank: 2, Objectives: ObjectiveValues{Cost: 11, Disruption: 0.1}},
+				{Rank: 3, Objectives: ObjectiveValues{Cost: 20, Disruption: 0.5}, NamedObjectives: map[string]float64{"power": 0.8}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		weights map[string]float64
+		indexes []int
+		want    []float64
+	}{
+		{
+			name:    "objectives normalized across solutions",
+			weights: map[string]float64{ObjectiveCost: 1, ObjectiveDisruption: 1},
+			indexes: []int{0, 1, 2},
+			want:    []float64{0.5, 0.05, 0.75},
+		},
+		{
+			name:    "normalized across the given solutions only",
+			weights: map[string]float64{ObjectiveCost: 1, ObjectiveDisruption: 1},
+			indexes: []int{2, 1},
+			want:    []float64{1, 0},
+		},
+		{
+			name:    "missing objective counts as zero",
+			weights: map[string]float64{"power": 1},
+			indexes: []int{0, 1, 2},
+			want:    []float64{0, 0, 1},
+		},
+		{
+			name:    "objective with the same value everywhere",
+			weights: map[string]float64{ObjectiveBalance: 1},
+			indexes: []int{0, 1, 2},
+			want:    []float64{0, 0, 0},
+		},
+		{
+			name:    "zero weights",
+			weights: map[string]float64{ObjectiveCost: 0},
+			indexes: []int{0, 1},
+			want:    []float64{0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := hint.ComputeNamedWeightedScores(tt.weights, tt.indexes)
+			if diff := cmp.Diff(tt.want, got, cmpopts.EquateApprox(0, 1e-9)); diff != "" {
+				t.Errorf("ComputeNamedWeightedScores() mismatch (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReplicaSetMovementSlotAccessors(t *testing.T) {
+	tests := []struct {
+		name          string
+		movement      *ReplicaSetMovement
+		wantTarget    int
+		wantAvailable int
+		wantScheduled int
+	}{
+		{
+			name:     "nil movement",
+			movement: nil,
+		},
+		{
+			name:     "movement without slot maps",
+			movement: &ReplicaSetMovement{ReplicaSetName: "web", Namespace: "default"},
+		},
+		{
+			name: "populated movement",
+			movement: &ReplicaSetMovement{
+				ReplicaSetName:     "web",
+				Namespace:          "default",
+				TargetDistribution: map[string]int{"node-a": 3},
+				AvailableSlots:     map[string]int{"node-a": 2},
+				ScheduledCount:     map[string]int{"node-a": 1},
+			},
+			wantTarget:    3,
+			wantAvailable: 2,
+			wantScheduled: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.movement.TargetCountFor("node-a"); got != tt.wantTarget {
+				t.Errorf("TargetCountFor() = %d, want %d", got, tt.wantTarget)
+			}
+			if got := tt.movement.AvailableSlotsFor("node-a"); got != tt.wantAvailable {
+				t.Errorf("AvailableSlotsFor() = %d, want %d", got, tt.wantAvailable)
+			}
+			if got := tt.movement.ScheduledCountFor("node-a"); got != tt.wantScheduled {
+				t.Errorf("ScheduledCountFor() = %d, want %d", got, tt.wantScheduled)
+			}
+		})
+	}
+}
+
+func TestReplicaSetMovementSetSlots(t *testing.T) {
+	movement := &ReplicaSetMovement{ReplicaSetName: "web", Namespace: "default"}
+	movement.SetSlots("node-a", 1, 2)
+	if got := movement.AvailableSlotsFor("node-a"); got != 1 {
+		t.Errorf("AvailableSlotsFor() = %d, want 1", got)
+	}
+	if got := movement.ScheduledCountFor("node-a"); got != 2 {
+		t.Errorf("ScheduledCountFor() = %d, want 2", got)
+	}
+
+	movement.SetSlots("node-b", 0, 1)
+	want := ReplicaSetMovement{
+		ReplicaSetName: "web",
+		Namespace:      "default",
+		AvailableSlots: map[string]int{"node-a": 1, "node-b": 0},
+		ScheduledCount: map[string]int{"node-a": 2, "node-b": 1},
+	}
+	if diff := cmp.Diff(want, *movement); diff != "" {
+		t.Errorf("unexpected movement (-want, +got):\n%s", diff)
+	}
+}
+
+func TestUpdateRemainingSlots(t *testing.T) {
+	hint := &SchedulingHint{
+		Spec: SchedulingHintSpec{
+			Solutions: []OptimizationSolution{
+				{
+					Rank: 1,
+					ReplicaSetMovements: []ReplicaSetMovement{
+						{ReplicaSetName: "web", Namespace: "default", AvailableSlots: map[string]int{"node-a": 2, "node-b": 1}},
+					},
+				},
+				{
+					Rank: 2,
+					ReplicaSetMovements: []ReplicaSetMovement{
+						{ReplicaSetName: "web", Namespace: "default", AvailableSlots: map[string]int{"node-c": 5}},
+					},
+				},
+			},
+		},
+	}
+
+	hint.UpdateRemainingSlots()
+	if hint.Status.RemainingSlots != 3 {
+		t.Errorf("RemainingSlots = %d, want 3 from the top solution only", hint.Status.RemainingSlots)
+	}
+
+	hint.Spec.Solutions[0].ReplicaSetMovements[0].AvailableSlots["node-a"] = 0
+	hint.UpdateRemainingSlots()
+	if hint.Status.RemainingSlots != 1 {
+		t.Errorf("RemainingSlots = %d after consuming slots, want 1", hint.Status.RemainingSlots)
+	}
+
+	hint.Spec.Solutions = nil
+	hint.UpdateRemainingSlots()
+	if hint.Status.RemainingSlots != 0 {
+		t.Errorf("RemainingSlots = %d without solutions, want 0", hint.Status.RemainingSlots)
+	}
+}
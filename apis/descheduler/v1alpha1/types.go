@@ -27,9 +27,11 @@ import (
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:scope=Cluster,shortName={hints,hint}
+// +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Phase",JSONPath=".status.phase",type=string,description="Current phase of the scheduling hints"
 // +kubebuilder:printcolumn:name="Solutions",JSONPath=".spec.solutions[*].rank",type=string,description="Number of optimization solutions"
+// +kubebuilder:printcolumn:name="Remaining",JSONPath=".status.remainingSlots",type=integer,description="Slots of the top solution not yet consumed by scheduled pods"
 // +kubebuilder:printcolumn:name="Age",JSONPath=".metadata.creationTimestamp",type=date,description="Age is the time SchedulingHint was created."
 type SchedulingHint struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -61,6 +63,16 @@ type SchedulingHintSpec struct {
 
 	// DeschedulerVersion is the version of descheduler that generated these hints
 	DeschedulerVersion string `json:"deschedulerVersion,omitempty"`
+
+	// ObjectiveWeights are the weights the descheduler used to compute each solution's WeightedScore,
+	// allowing consumers to re-rank the solutions under different preferences
+	// +optional
+	ObjectiveWeights *ObjectiveWeights `json:"objectiveWeights,omitempty"`
+
+	// Seed is the random seed the descheduler's optimizer used to generate the solutions,
+	// allowing the optimization to be reproduced offline
+	// +optional
+	Seed *int64 `json:"seed,omitempty"`
 }
 
 // ReplicaSetDistribution represents the distribution of a ReplicaSet across nodes
@@ -84,6 +96,10 @@ type SchedulingHintStatus struct {
 	// AppliedMovements is the number of movements that have been applied
 	AppliedMovements int `json:"appliedMovements,omitempty"`
 
+	// RemainingSlots is the number of available slots summed across the top solution's movements
+	// +optional
+	RemainingSlots int `json:"remainingSlots,omitempty"`
+
 	// LastAppliedTime is when movements were last applied
 	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
 
@@ -108,6 +124,8 @@ const (
 // OptimizationSolution represents a single solution from multi-objective optimization
 type OptimizationSolution struct {
 	// Rank is the solution rank in Pareto front (1 = best)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
 	Rank int `json:"rank"`
 
 	// WeightedScore is the weighted objective score
@@ -116,6 +134,11 @@ type OptimizationSolution struct {
 	// Objectives contains the individual objective values
 	Objectives ObjectiveValues `json:"objectives"`
 
+	// NamedObjectives contains objective values by name, including objectives not covered by
+	// Objectives such as power or carbon. A value here takes precedence over the same objective in Objectives
+	// +optional
+	NamedObjectives map[string]float64 `json:"namedObjectives,omitempty"`
+
 	// MovementCount is the total number of pod movements in this solution
 	MovementCount int `json:"movementCount"`
 
@@ -135,6 +158,21 @@ type ObjectiveValues struct {
 	Balance float64 `json:"balance"`
 }
 
+// ObjectiveWeights contains the weight given to each optimization objective
+type ObjectiveWeights struct {
+	// Cost is the weight of the cost objective
+	// +kubebuilder:validation:Minimum=0
+	Cost float64 `json:"cost"`
+
+	// Disruption is the weight of the disruption objective
+	// +kubebuilder:validation:Minimum=0
+	Disruption float64 `json:"disruption"`
+
+	// Balance is the weight of the balance objective
+	// +kubebuilder:validation:Minimum=0
+	Balance float64 `json:"balance"`
+}
+
 // ReplicaSetMovement represents a ReplicaSet-level movement recommendation with atomic slot tracking
 type ReplicaSetMovement struct {
 	// ReplicaSetName is the name of the ReplicaSet
@@ -149,10 +187,12 @@ type ReplicaSetMovement struct {
 
 	// AvailableSlots tracks remaining scheduling slots (decrements as pods are scheduled)
 	// Key: node name, Value: remaining available slots for atomic reservation
+	// +kubebuilder:validation:XValidation:rule="self.all(node, self[node] >= 0)",message="available slots must be non-negative"
 	AvailableSlots map[string]int `json:"availableSlots"`
 
 	// ScheduledCount tracks how many pods have been successfully scheduled to each node
 	// Key: node name, Value: number of pods already scheduled via this hint
+	// +kubebuilder:validation:XValidation:rule="self.all(node, self[node] >= 0)",message="scheduled counts must be non-negative"
 	ScheduledCount map[string]int `json:"scheduledCount,omitempty"`
 
 	// Reason provides the optimization rationale for this movement
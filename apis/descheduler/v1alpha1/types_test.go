@@ -0,0 +1,194 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestSchedulingHintSeedRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		seed *int64
+	}{
+		{
+			name: "seed recorded",
+			seed: ptr.To[int64](42),
+		},
+		{
+			name: "seed not recorded",
+			seed: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hint := &SchedulingHint{
+				ObjectMeta: metav1.ObjectMeta{Name: "multiobjective-hints-abc"},
+				Spec: SchedulingHintSpec{
+					ClusterFingerprint: "abc",
+					ClusterNodes:       []string{"node-a"},
+					Seed:               tt.seed,
+				},
+			}
+
+			data, err := json.Marshal(hint)
+			if err != nil {
+				t.Fatalf("failed to marshal hint: %v", err)
+			}
+			decoded := &SchedulingHint{}
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("failed to unmarshal hint: %v", err)
+			}
+			if diff := cmp.Diff(hint.Spec.Seed, decoded.Spec.Seed); diff != "" {
+				t.Errorf("unexpected seed after round trip (-want, +got):\n%s", diff)
+			}
+
+			copied := hint.DeepCopy()
+			if diff := cmp.Diff(hint.Spec.Seed, copied.Spec.Seed); diff != "" {
+				t.Errorf("unexpected seed after deep copy (-want, +got):\n%s", diff)
+			}
+			if tt.seed != nil {
+				*copied.Spec.Seed = 7
+				if *hint.Spec.Seed != *tt.seed {
+					t.Errorf("deep copy shares seed with original")
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulingHintObjectiveWeightsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights *ObjectiveWeights
+	}{
+		{
+			name:    "weights recorded",
+			weights: &ObjectiveWeights{Cost: 0.5, Disruption: 0.3, Balance: 0.2},
+		},
+		{
+			name:    "weights not recorded",
+			weights: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hint := &SchedulingHint{
+				ObjectMeta: metav1.ObjectMeta{Name: "multiobjective-hints-abc"},
+				Spec: SchedulingHintSpec{
+					ClusterFingerprint: "abc",
+					ClusterNodes:       []string{"node-a"},
+					ObjectiveWeights:   tt.weights,
+				},
+			}
+
+			data, err := json.Marshal(hint)
+			if err != nil {
+				t.Fatalf("failed to marshal hint: %v", err)
+			}
+			decoded := &SchedulingHint{}
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("failed to unmarshal hint: %v", err)
+			}
+			if diff := cmp.Diff(hint.Spec.ObjectiveWeights, decoded.Spec.ObjectiveWeights); diff != "" {
+				t.Errorf("unexpected objective weights after round trip (-want, +got):\n%s", diff)
+			}
+
+			copied := hint.DeepCopy()
+			if diff := cmp.Diff(hint.Spec.ObjectiveWeights, copied.Spec.ObjectiveWeights); diff != "" {
+				t.Errorf("unexpected objective weights after deep copy (-want, +got):\n%s", diff)
+			}
+			if tt.weights != nil {
+				copied.Spec.ObjectiveWeights.Cost = 1
+				if hint.Spec.ObjectiveWeights.Cost != tt.weights.Cost {
+					t.Errorf("deep copy shares objective weights with original")
+				}
+			}
+		})
+	}
+}
+
+func TestOptimizationSolutionNamedObjectivesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name       string
+		objectives map[string]float64
+	}{
+		{
+			name: "five named objectives",
+			objectives: map[string]float64{
+				"cost":       0.1,
+				"disruption": 0.2,
+				"balance":    0.3,
+				"power":      0.4,
+				"carbon":     0.5,
+			},
+		},
+		{
+			name:       "no named objectives",
+			objectives: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hint := &SchedulingHint{
+				ObjectMeta: metav1.ObjectMeta{Name: "multiobjective-hints-abc"},
+				Spec: SchedulingHintSpec{
+					ClusterFingerprint: "abc",
+					ClusterNodes:       []string{"node-a"},
+					Solutions: []OptimizationSolution{
+						{
+							Rank:            1,
+							Objectives:      ObjectiveValues{Cost: 0.1, Disruption: 0.2, Balance: 0.3},
+							NamedObjectives: tt.objectives,
+						},
+					},
+				},
+			}
+
+			data, err := json.Marshal(hint)
+			if err != nil {
+				t.Fatalf("failed to marshal hint: %v", err)
+			}
+			decoded := &SchedulingHint{}
+			if err := json.Unmarshal(data, decoded); err != nil {
+				t.Fatalf("failed to unmarshal hint: %v", err)
+			}
+			if diff := cmp.Diff(hint.Spec.Solutions, decoded.Spec.Solutions); diff != "" {
+				t.Errorf("unexpected solutions after round trip (-want, +got):\n%s", diff)
+			}
+
+			copied := hint.DeepCopy()
+			if diff := cmp.Diff(hint.Spec.Solutions, copied.Spec.Solutions); diff != "" {
+				t.Errorf("unexpected solutions after deep copy (-want, +got):\n%s", diff)
+			}
+			if tt.objectives != nil {
+				copied.Spec.Solutions[0].NamedObjectives["power"] = 1
+				if hint.Spec.Solutions[0].NamedObjectives["power"] != tt.objectives["power"] {
+					t.Errorf("deep copy shares named objectives with original")
+				}
+			}
+		})
+	}
+}
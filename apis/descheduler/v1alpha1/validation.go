@@ -0,0 +1,67 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"fmt"
+	"sort"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// MinSolutionRank is the best rank a solution can have in the Pareto front
+	MinSolutionRank = 1
+	// MaxSolutionRank is the worst rank a solution can have in the Pareto front
+	MaxSolutionRank = 1000
+)
+
+// ValidateSchedulingHint mirrors the CRD's schema validation so consumers can reject hints that were
+// persisted before the validation existed or were written by a buggy client
+func ValidateSchedulingHint(hint *SchedulingHint) error {
+	var allErrs field.ErrorList
+	solutionsPath := field.NewPath("spec", "solutions")
+	for i, solution := range hint.Spec.Solutions {
+		solutionPath := solutionsPath.Index(i)
+		if solution.Rank < MinSolutionRank || solution.Rank > MaxSolutionRank {
+			allErrs = append(allErrs, field.Invalid(solutionPath.Child("rank"), solution.Rank,
+				fmt.Sprintf("must be between %d and %d", MinSolutionRank, MaxSolutionRank)))
+		}
+		for j, movement := range solution.ReplicaSetMovements {
+			movementPath := solutionPath.Child("replicaSetMovements").Index(j)
+			allErrs = append(allErrs, validateNonNegativeCounts(movementPath.Child("availableSlots"), movement.AvailableSlots)...)
+			allErrs = append(allErrs, validateNonNegativeCounts(movementPath.Child("scheduledCount"), movement.ScheduledCount)...)
+		}
+	}
+	return allErrs.ToAggregate()
+}
+
+func validateNonNegativeCounts(path *field.Path, counts map[string]int) field.ErrorList {
+	nodes := make([]string, 0, len(counts))
+	for node := range counts {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+
+	var allErrs field.ErrorList
+	for _, node := range nodes {
+		if counts[node] < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Key(node), counts[node], "must be non-negative"))
+		}
+	}
+	return allErrs
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"testing"
+)
+
+func TestValidateSchedulingHint(t *testing.T) {
+	validMovement := func() ReplicaSetMovement {
+		return ReplicaSetMovement{
+			ReplicaSetName:     "web",
+			Namespace:          "default",
+			TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+			AvailableSlots:     map[string]int{"node-a": 1, "node-b": 0},
+			ScheduledCount:     map[string]int{"node-a": 1, "node-b": 1},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(hint *SchedulingHint)
+		wantErr string
+	}{
+		{
+			name:   "valid hint",
+			mutate: func(hint *SchedulingHint) {},
+		},
+		{
+			name: "hint without solutions",
+			mutate: func(hint *SchedulingHint) {
+				hint.Spec.Solutions = nil
+			},
+		},
+		{
+			name: "negative available slots",
+			mutate: func(hint *SchedulingHint) {
+				hint.Spec.Solutions[0].ReplicaSetMovements[0].AvailableSlots["node-b"] = -1
+			},
+			wantErr: "spec.solutions[0].replicaSetMovements[0].availableSlots[node-b]: Invalid value: -1: must be non-negative",
+		},
+		{
+			name: "negative scheduled count",
+			mutate: func(hint *SchedulingHint) {
+				hint.Spec.Solutions[1].ReplicaSetMovements[0].ScheduledCount["node-a"] = -2
+			},
+			wantErr: "spec.solutions[1].replicaSetMovements[0].scheduledCount[node-a]: Invalid value: -2: must be non-negative",
+		},
+		{
+			name: "rank below minimum",
+			mutate: func(hint *SchedulingHint) {
+				hint.Spec.Solutions[0].Rank = 0
+			},
+			wantErr: "spec.solutions[0].rank: Invalid value: 0: must be between 1 and 1000",
+		},
+		{
+			name: "rank above maximum",
+			mutate: func(hint *SchedulingHint) {
+				hint.Spec.Solutions[1].Rank = MaxSolutionRank + 1
+			},
+			wantErr: "spec.solutions[1].rank: Invalid value: 1001: must be between 1 and 1000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hint := &SchedulingHint{
+				Spec: SchedulingHintSpec{
+					Solutions: []OptimizationSolution{
+						{Rank: 1, ReplicaSetMovements: []ReplicaSetMovement{validMovement()}},
+						{Rank: 2, ReplicaSetMovements: []ReplicaSetMovement{validMovement()}},
+					},
+				},
+			}
+			tt.mutate(hint)
+
+			err := ValidateSchedulingHint(hint)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateSchedulingHint() unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.wantErr {
+				t.Errorf("ValidateSchedulingHint() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
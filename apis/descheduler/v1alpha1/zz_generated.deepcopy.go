@@ -40,10 +40,32 @@ func (in *ObjectiveValues) DeepCopy() *ObjectiveValues {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectiveWeights) DeepCopyInto(out *ObjectiveWeights) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectiveWeights.
+func (in *ObjectiveWeights) DeepCopy() *ObjectiveWeights {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectiveWeights)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OptimizationSolution) DeepCopyInto(out *OptimizationSolution) {
 	*out = *in
 	out.Objectives = in.Objectives
+	if in.NamedObjectives != nil {
+		in, out := &in.NamedObjectives, &out.NamedObjectives
+		*out = make(map[string]float64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 	if in.ReplicaSetMovements != nil {
 		in, out := &in.ReplicaSetMovements, &out.ReplicaSetMovements
 		*out = make([]ReplicaSetMovement, len(*in))
@@ -210,6 +232,16 @@ func (in *SchedulingHintSpec) DeepCopyInto(out *SchedulingHintSpec) {
 		in, out := &in.GeneratedAt, &out.GeneratedAt
 		*out = (*in).DeepCopy()
 	}
+	if in.ObjectiveWeights != nil {
+		in, out := &in.ObjectiveWeights, &out.ObjectiveWeights
+		*out = new(ObjectiveWeights)
+		**out = **in
+	}
+	if in.Seed != nil {
+		in, out := &in.Seed, &out.Seed
+		*out = new(int64)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SchedulingHintSpec.
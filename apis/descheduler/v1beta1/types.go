@@ -0,0 +1,212 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SchedulingHint is a cluster-scoped resource that contains hints from the descheduler
+// about optimal pod placements for the scheduler to consume. The version is not served
+// until a conversion webhook translates it to and from the v1alpha1 storage version
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName={hints,hint}
+// +kubebuilder:unservedversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Phase",JSONPath=".status.phase",type=string,description="Current phase of the scheduling hints"
+// +kubebuilder:printcolumn:name="Solutions",JSONPath=".spec.solutions[*].rank",type=string,description="Number of optimization solutions"
+// +kubebuilder:printcolumn:name="Remaining",JSONPath=".status.remainingSlots",type=integer,description="Slots of the top solution not yet consumed by scheduled pods"
+// +kubebuilder:printcolumn:name="Age",JSONPath=".metadata.creationTimestamp",type=date,description="Age is the time SchedulingHint was created."
+type SchedulingHint struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SchedulingHintSpec   `json:"spec,omitempty"`
+	Status SchedulingHintStatus `json:"status,omitempty"`
+}
+
+// SchedulingHintSpec defines the desired state of SchedulingHint
+type SchedulingHintSpec struct {
+	// ClusterFingerprint is a hash of the cluster state for quick comparison
+	// +kubebuilder:validation:MinLength=1
+	ClusterFingerprint string `json:"clusterFingerprint"`
+
+	// ClusterNodes contains the list of node names that existed when solutions were generated
+	ClusterNodes []string `json:"clusterNodes"`
+
+	// OriginalReplicaSetDistribution stores the ReplicaSet distribution when optimization was performed
+	OriginalReplicaSetDistribution []ReplicaSetDistribution `json:"originalReplicaSetDistribution"`
+
+	// Solutions contains optimization solutions from multi-objective algorithms
+	Solutions []OptimizationSolution `json:"solutions"`
+
+	// ExpirationTime is when these hints should no longer be used
+	ExpirationTime *metav1.Time `json:"expirationTime"`
+
+	// GeneratedAt indicates when these hints were generated
+	GeneratedAt *metav1.Time `json:"generatedAt"`
+
+	// DeschedulerVersion is the version of descheduler that generated these hints
+	DeschedulerVersion string `json:"deschedulerVersion,omitempty"`
+
+	// ObjectiveWeights are the weights the descheduler used to compute each solution's WeightedScore,
+	// allowing consumers to re-rank the solutions under different preferences
+	// +optional
+	ObjectiveWeights *ObjectiveWeights `json:"objectiveWeights,omitempty"`
+
+	// Seed is the random seed the descheduler's optimizer used to generate the solutions,
+	// allowing the optimization to be reproduced offline
+	// +optional
+	Seed *int64 `json:"seed,omitempty"`
+}
+
+// ReplicaSetDistribution represents the distribution of a ReplicaSet across nodes
+type ReplicaSetDistribution struct {
+	// Namespace of the ReplicaSet
+	Namespace string `json:"namespace"`
+
+	// ReplicaSetName is the name of the ReplicaSet
+	ReplicaSetName string `json:"replicaSetName"`
+
+	// NodeDistribution maps node names to the number of pods on each node
+	NodeDistribution map[string]int `json:"nodeDistribution"`
+}
+
+// SchedulingHintStatus defines the observed state of SchedulingHint
+type SchedulingHintStatus struct {
+	// Phase represents the current phase of the scheduling hints
+	// +kubebuilder:validation:Enum=Active;Expired;Applied
+	Phase SchedulingHintPhase `json:"phase,omitempty"`
+
+	// AppliedMovements is the number of movements that have been applied
+	AppliedMovements int `json:"appliedMovements,omitempty"`
+
+	// RemainingSlots is the number of available slots summed across the top solution's movements
+	// +optional
+	RemainingSlots int `json:"remainingSlots,omitempty"`
+
+	// LastAppliedTime is when movements were last applied
+	LastAppliedTime *metav1.Time `json:"lastAppliedTime,omitempty"`
+
+	// Conditions represent the latest available observations of the hint's current state
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// SchedulingHintPhase represents the phase of scheduling hints
+type SchedulingHintPhase string
+
+const (
+	// SchedulingHintPhaseActive indicates the hints are active and can be used
+	SchedulingHintPhaseActive SchedulingHintPhase = "Active"
+
+	// SchedulingHintPhaseExpired indicates the hints have expired
+	SchedulingHintPhaseExpired SchedulingHintPhase = "Expired"
+
+	// SchedulingHintPhaseApplied indicates the hints have been applied
+	SchedulingHintPhaseApplied SchedulingHintPhase = "Applied"
+)
+
+// OptimizationSolution represents a single solution from multi-objective optimization
+type OptimizationSolution struct {
+	// Rank is the solution rank in Pareto front (1 = best)
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=1000
+	Rank int `json:"rank"`
+
+	// WeightedScore is the weighted objective score
+	WeightedScore float64 `json:"weightedScore"`
+
+	// Objectives contains the individual objective values
+	Objectives ObjectiveValues `json:"objectives"`
+
+	// NamedObjectives contains objective values by name, including objectives not covered by
+	// Objectives such as power or carbon. A value here takes precedence over the same objective in Objectives
+	// +optional
+	NamedObjectives map[string]float64 `json:"namedObjectives,omitempty"`
+
+	// MovementCount is the total number of pod movements in this solution
+	MovementCount int `json:"movementCount"`
+
+	// ReplicaSetMovements contains ReplicaSet-level movement recommendations
+	ReplicaSetMovements []ReplicaSetMovement `json:"replicaSetMovements"`
+}
+
+// ObjectiveValues contains the values for each optimization objective
+type ObjectiveValues struct {
+	// Cost is the effective cost objective value
+	Cost float64 `json:"cost"`
+
+	// Disruption is the disruption objective value
+	Disruption float64 `json:"disruption"`
+
+	// Balance is the balance objective value
+	Balance float64 `json:"balance"`
+}
+
+// ObjectiveWeights contains the weight given to each optimization objective
+type ObjectiveWeights struct {
+	// Cost is the weight of the cost objective
+	// +kubebuilder:validation:Minimum=0
+	Cost float64 `json:"cost"`
+
+	// Disruption is the weight of the disruption objective
+	// +kubebuilder:validation:Minimum=0
+	Disruption float64 `json:"disruption"`
+
+	// Balance is the weight of the balance objective
+	// +kubebuilder:validation:Minimum=0
+	Balance float64 `json:"balance"`
+}
+
+// ReplicaSetMovement represents a ReplicaSet-level movement recommendation with atomic slot tracking
+type ReplicaSetMovement struct {
+	// ReplicaSetName is the name of the ReplicaSet
+	ReplicaSetName string `json:"replicaSetName"`
+
+	// Namespace is the namespace of the ReplicaSet
+	Namespace string `json:"namespace"`
+
+	// TargetDistribution specifies how replicas should be distributed across nodes
+	// Key: node name, Value: target number of replicas
+	TargetDistribution map[string]int `json:"targetDistribution"`
+
+	// AvailableSlots tracks remaining scheduling slots (decrements as pods are scheduled)
+	// Key: node name, Value: remaining available slots for atomic reservation
+	// +kubebuilder:validation:XValidation:rule="self.all(node, self[node] >= 0)",message="available slots must be non-negative"
+	AvailableSlots map[string]int `json:"availableSlots"`
+
+	// ScheduledCount tracks how many pods have been successfully scheduled to each node
+	// Key: node name, Value: number of pods already scheduled via this hint
+	// +kubebuilder:validation:XValidation:rule="self.all(node, self[node] >= 0)",message="scheduled counts must be non-negative"
+	ScheduledCount map[string]int `json:"scheduledCount,omitempty"`
+
+	// Reason provides the optimization rationale for this movement
+	Reason string `json:"reason"`
+}
+
+// +kubebuilder:object:root=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SchedulingHintList contains a list of SchedulingHint
+type SchedulingHintList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SchedulingHint `json:"items"`
+}
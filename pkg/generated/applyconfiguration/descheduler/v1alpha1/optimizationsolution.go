@@ -24,6 +24,7 @@ type OptimizationSolutionApplyConfiguration struct {
 	Rank                *int                                   `json:"rank,omitempty"`
 	WeightedScore       *float64                               `json:"weightedScore,omitempty"`
 	Objectives          *ObjectiveValuesApplyConfiguration     `json:"objectives,omitempty"`
+	NamedObjectives     map[string]float64                     `json:"namedObjectives,omitempty"`
 	MovementCount       *int                                   `json:"movementCount,omitempty"`
 	ReplicaSetMovements []ReplicaSetMovementApplyConfiguration `json:"replicaSetMovements,omitempty"`
 }
@@ -58,6 +59,20 @@ func (b *OptimizationSolutionApplyConfiguration) WithObjectives(value *Objective
 	return b
 }
 
+// WithNamedObjectives puts the entries into the NamedObjectives field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the NamedObjectives field,
+// overwriting an existing map entries in NamedObjectives field with the same key.
+func (b *OptimizationSolutionApplyConfiguration) WithNamedObjectives(entries map[string]float64) *OptimizationSolutionApplyConfiguration {
+	if b.NamedObjectives == nil && len(entries) > 0 {
+		b.NamedObjectives = make(map[string]float64, len(entries))
+	}
+	for k, v := range entries {
+		b.NamedObjectives[k] = v
+	}
+	return b
+}
+
 // WithMovementCount sets the MovementCount field in the declarative configuration to the given value
 // and returns the receiver, so that objects can be built by chaining "With" function invocations.
 // If called multiple times, the MovementCount field is set to the value of the last call.
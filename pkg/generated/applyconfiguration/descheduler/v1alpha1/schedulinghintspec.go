@@ -32,6 +32,7 @@ type SchedulingHintSpecApplyConfiguration struct {
 	ExpirationTime                 *v1.Time                                   `json:"expirationTime,omitempty"`
 	GeneratedAt                    *v1.Time                                   `json:"generatedAt,omitempty"`
 	DeschedulerVersion             *string                                    `json:"deschedulerVersion,omitempty"`
+	Seed                           *int64                                     `json:"seed,omitempty"`
 }
 
 // SchedulingHintSpecApplyConfiguration constructs a declarative configuration of the SchedulingHintSpec type for use with
@@ -107,3 +108,11 @@ func (b *SchedulingHintSpecApplyConfiguration) WithDeschedulerVersion(value stri
 	b.DeschedulerVersion = &value
 	return b
 }
+
+// WithSeed sets the Seed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Seed field is set to the value of the last call.
+func (b *SchedulingHintSpecApplyConfiguration) WithSeed(value int64) *SchedulingHintSpecApplyConfiguration {
+	b.Seed = &value
+	return b
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"sync"
+	"time"
+
+	"k8s.io/utils/clock"
+)
+
+const (
+	// Number of consecutive hint lookup misses that trips the breaker
+	breakerMissThreshold = 20
+
+	// Initial and maximum durations hint lookups stay disabled once the breaker trips
+	breakerInitialBackoff = 5 * time.Second
+	breakerMaxBackoff     = 5 * time.Minute
+)
+
+// hintLookupBreaker is a circuit breaker that temporarily disables hint lookups when the
+// cluster changes so fast that every fingerprint lookup misses. Each time the breaker trips
+// again without an intervening hit, the disabled period doubles up to breakerMaxBackoff
+type hintLookupBreaker struct {
+	mu                sync.Mutex
+	clock             clock.PassiveClock
+	consecutiveMisses int
+	backoff           time.Duration
+	openUntil         time.Time
+}
+
+func newHintLookupBreaker(clock clock.PassiveClock) *hintLookupBreaker {
+	return &hintLookupBreaker{
+		clock:   clock,
+		backoff: breakerInitialBackoff,
+	}
+}
+
+// Allow returns whether a hint lookup should be attempted
+func (b *hintLookupBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.clock.Now().Before(b.openUntil) {
+		return false
+	}
+	hintLookupBreakerOpen.Set(0)
+	return true
+}
+
+// Record records the outcome of a hint lookup, tripping the breaker after a sustained run of misses
+func (b *hintLookupBreaker) Record(hit bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if hit {
+		b.consecutiveMisses = 0
+		b.backoff = breakerInitialBackoff
+		return
+	}
+
+	b.consecutiveMisses++
+	if b.consecutiveMisses < breakerMissThreshold {
+		return
+	}
+
+	// Trip the breaker and back off further if it trips again before a hit resets it
+	b.openUntil = b.clock.Now().Add(b.backoff)
+	b.backoff *= 2
+	if b.backoff > breakerMaxBackoff {
+		b.backoff = breakerMaxBackoff
+	}
+	b.consecutiveMisses = 0
+	hintLookupBreakerOpen.Set(1)
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"testing"
+	"time"
+
+	"k8s.io/component-base/metrics/testutil"
+	clocktesting "k8s.io/utils/clock/testing"
+)
+
+func assertBreakerGauge(t *testing.T, expected float64) {
+	t.Helper()
+	value, err := testutil.GetGaugeMetricValue(hintLookupBreakerOpen)
+	if err != nil {
+		t.Fatalf("failed to read breaker gauge: %v", err)
+	}
+	if value != expected {
+		t.Errorf("breaker gauge = %v, want %v", value, expected)
+	}
+}
+
+func TestHintLookupBreakerTripsOnSustainedMisses(t *testing.T) {
+	RegisterMetrics()
+	fakeClock := clocktesting.NewFakePassiveClock(time.Now())
+	b := newHintLookupBreaker(fakeClock)
+
+	for i := 0; i < breakerMissThreshold-1; i++ {
+		if !b.Allow() {
+			t.Fatalf("breaker opened after %d misses, want %d", i, breakerMissThreshold)
+		}
+		b.Record(false)
+	}
+	if !b.Allow() {
+		t.Fatalf("breaker opened before reaching the miss threshold")
+	}
+	assertBreakerGauge(t, 0)
+
+	// The final miss trips the breaker
+	b.Record(false)
+	if b.Allow() {
+		t.Fatalf("breaker still closed after %d consecutive misses", breakerMissThreshold)
+	}
+	assertBreakerGauge(t, 1)
+
+	// Lookups are re-enabled once the initial backoff elapses
+	fakeClock.SetTime(fakeClock.Now().Add(breakerInitialBackoff))
+	if !b.Allow() {
+		t.Fatalf("breaker still open after initial backoff")
+	}
+	assertBreakerGauge(t, 0)
+
+	// Tripping again without a hit doubles the disabled period
+	for i := 0; i < breakerMissThreshold; i++ {
+		b.Record(false)
+	}
+	fakeClock.SetTime(fakeClock.Now().Add(breakerInitialBackoff))
+	if b.Allow() {
+		t.Fatalf("breaker re-enabled after initial backoff, want exponential backoff")
+	}
+	fakeClock.SetTime(fakeClock.Now().Add(breakerInitialBackoff))
+	if !b.Allow() {
+		t.Fatalf("breaker still open after doubled backoff")
+	}
+}
+
+func TestHintLookupBreakerResetsOnHit(t *testing.T) {
+	RegisterMetrics()
+	fakeClock := clocktesting.NewFakePassiveClock(time.Now())
+	b := newHintLookupBreaker(fakeClock)
+
+	for i := 0; i < breakerMissThreshold-1; i++ {
+		b.Record(false)
+	}
+	b.Record(true)
+	for i := 0; i < breakerMissThreshold-1; i++ {
+		b.Record(false)
+	}
+	if !b.Allow() {
+		t.Errorf("breaker opened although misses were interrupted by a hit")
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"sync"
+
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/legacyregistry"
+)
+
+const (
+	// metricsSubsystem is the prefix of all metrics exposed by the plugin
+	metricsSubsystem = "multiobjective"
+
+	// Result label values of hintLookupTotal
+	hintLookupHit  = "hit"
+	hintLookupMiss = "miss"
+
+	// Result label values of slotConsumeTotal
+	slotConsumeSuccess  = "success"
+	slotConsumeConflict = "conflict"
+	slotConsumeEmpty    = "empty"
+	slotConsumeError    = "error"
+)
+
+var (
+	// hintLookupBreakerOpen reports whether hint lookups are currently disabled by the circuit breaker
+	hintLookupBreakerOpen = metrics.NewGauge(
+		&metrics.GaugeOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "hint_lookup_breaker_open",
+			Help:           "Whether scheduling hint lookups are disabled due to a sustained high miss rate (1 = open, 0 = closed).",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	// hintLookupTotal counts scheduling hint lookups by whether a usable hint was found
+	hintLookupTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "hint_lookup_total",
+			Help:           "Number of scheduling hint lookups by result (hit or miss).",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"result"})
+
+	// hintLookupDuration observes the latency of scheduling hint lookups, including the fingerprint calculation
+	hintLookupDuration = metrics.NewHistogram(
+		&metrics.HistogramOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "hint_lookup_duration_seconds",
+			Help:           "Latency of scheduling hint lookups in seconds.",
+			Buckets:        metrics.ExponentialBuckets(0.0005, 2, 14),
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	// hintNodeMismatchTotal counts scheduling hints skipped because their nodes do not match the cluster
+	hintNodeMismatchTotal = metrics.NewCounter(
+		&metrics.CounterOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "hint_node_mismatch_total",
+			Help:           "Number of scheduling hints skipped because they reference nodes that no longer exist or miss nodes that joined.",
+			StabilityLevel: metrics.ALPHA,
+		})
+
+	// slotConsumeTotal counts attempts to consume a slot in a scheduling hint by result
+	slotConsumeTotal = metrics.NewCounterVec(
+		&metrics.CounterOpts{
+			Subsystem:      metricsSubsystem,
+			Name:           "slot_consume_total",
+			Help:           "Number of scheduling hint slot consumption attempts by result (success, conflict, empty or error).",
+			StabilityLevel: metrics.ALPHA,
+		}, []string{"result"})
+
+	registerMetricsOnce sync.Once
+)
+
+// RegisterMetrics registers the plugin metrics with the legacy registry
+func RegisterMetrics() {
+	registerMetricsOnce.Do(func() {
+		legacyregistry.MustRegister(
+			hintLookupBreakerOpen,
+			hintLookupTotal,
+			hintLookupDuration,
+			hintNodeMismatchTotal,
+			slotConsumeTotal,
+		)
+	})
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/component-base/metrics"
+	"k8s.io/component-base/metrics/testutil"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+)
+
+func counterValue(t *testing.T, m metrics.CounterMetric) float64 {
+	t.Helper()
+	value, err := testutil.GetCounterMetricValue(m)
+	if err != nil {
+		t.Fatalf("failed to read counter: %v", err)
+	}
+	return value
+}
+
+func histogramCount(t *testing.T, m metrics.ObserverMetric) uint64 {
+	t.Helper()
+	count, err := testutil.GetHistogramMetricCount(m)
+	if err != nil {
+		t.Fatalf("failed to read histogram: %v", err)
+	}
+	return count
+}
+
+func TestHintLookupAndSlotMetrics(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj()}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+	nodeInfos := []*framework.NodeInfo{makeNodeInfo(nodes[0])}
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 3))
+
+	hits := counterValue(t, hintLookupTotal.WithLabelValues(hintLookupHit))
+	misses := counterValue(t, hintLookupTotal.WithLabelValues(hintLookupMiss))
+	lookups := histogramCount(t, hintLookupDuration.ObserverMetric)
+	successes := counterValue(t, slotConsumeTotal.WithLabelValues(slotConsumeSuccess))
+	empties := counterValue(t, slotConsumeTotal.WithLabelValues(slotConsumeEmpty))
+
+	// Miss: no hint exists for the current fingerprint
+	if status := s.PreScore(ctx, framework.NewCycleState(), pod, nodeInfos); !status.IsSuccess() {
+		t.Fatalf("PreScore() unexpected status: %v", status)
+	}
+	if got := counterValue(t, hintLookupTotal.WithLabelValues(hintLookupMiss)); got != misses+1 {
+		t.Errorf("hint lookup misses = %v, want %v", got, misses+1)
+	}
+	if got := counterValue(t, hintLookupTotal.WithLabelValues(hintLookupHit)); got != hits {
+		t.Errorf("hint lookup hits = %v, want %v", got, hits)
+	}
+
+	// Hit: the hint has one slot left on the target node
+	createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 1},
+				AvailableSlots:     map[string]int{"node-a": 1},
+			},
+		},
+	})
+	state := framework.NewCycleState()
+	if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+		t.Fatalf("PreScore() unexpected status: %v", status)
+	}
+	if got := counterValue(t, hintLookupTotal.WithLabelValues(hintLookupHit)); got != hits+1 {
+		t.Errorf("hint lookup hits = %v, want %v", got, hits+1)
+	}
+	if got := histogramCount(t, hintLookupDuration.ObserverMetric); got != lookups+2 {
+		t.Errorf("hint lookup latency observations = %v, want %v", got, lookups+2)
+	}
+
+	// The first Reserve consumes the last slot, the second finds none left and, without a filter flag,
+	// lets the pod through without a slot
+	if status := s.Reserve(ctx, state, pod, "node-a"); !status.IsSuccess() {
+		t.Fatalf("Reserve() unexpected status: %v", status)
+	}
+	if got := counterValue(t, slotConsumeTotal.WithLabelValues(slotConsumeSuccess)); got != successes+1 {
+		t.Errorf("slot consume successes = %v, want %v", got, successes+1)
+	}
+	if status := s.Reserve(ctx, state, pod, "node-a"); !status.IsSuccess() {
+		t.Fatalf("Reserve() unexpected status: %v", status)
+	}
+	if got := counterValue(t, slotConsumeTotal.WithLabelValues(slotConsumeEmpty)); got != empties+1 {
+		t.Errorf("slot consume empties = %v, want %v", got, empties+1)
+	}
+}
@@ -19,19 +19,31 @@ package multiobjective
 import (
 	"context"
 	"crypto/sha256"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/client-go/rest"
-	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/utils/clock"
+	"sigs.k8s.io/scheduler-plugins/apis/config"
 	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
 	"sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned"
 )
@@ -42,6 +54,15 @@ const (
 	// CycleState key for storing the selected target node
 	stateKey = "MultiObjective"
 
+	// CycleState key for storing the scheduling hint looked up in PreFilter
+	hintStateKey = "MultiObjectiveHint"
+
+	// Slot update retries back off exponentially with jitter, so schedulers competing for the same
+	// hint do not keep conflicting in lockstep
+	slotUpdateInitialBackoff = 10 * time.Millisecond
+	slotUpdateBackoffFactor  = 2.0
+	slotUpdateBackoffJitter  = 0.5
+
 	// Scoring constants
 	MinNodeScore = int64(0)   // Minimum score (let NodeResourcesFit take over)
 	MaxNodeScore = int64(100) // Maximum score (prefer this node)
@@ -49,39 +70,146 @@ const (
 
 // MultiObjectiveState stores the selected target node for the current scheduling cycle
 type MultiObjectiveState struct {
-	TargetNode string                              // The node selected for this pod based on scheduling hints
-	HasHint    bool                                // Whether we found a valid scheduling hint
-	Hint       *deschedulerv1alpha1.SchedulingHint // The scheduling hint for slot consumption
-	RSKey      string                              // The ReplicaSet key for this pod
+	TargetNode         string                              // The node selected for this pod based on scheduling hints
+	TargetWeights      map[string]int                      // Target counts of all eligible target nodes, used by NormalizeScore
+	HasHint            bool                                // Whether we found a valid scheduling hint
+	Hint               *deschedulerv1alpha1.SchedulingHint // The scheduling hint for slot consumption
+	SolutionIndex      int                                 // The index of the hint solution used for this pod
+	RSKey              string                              // The ReplicaSet key for this pod
+	SlotConsumed       bool                                // Whether Reserve consumed a slot on the target node
+	ScheduledCount     int                                 // The target node's ScheduledCount written when Reserve consumed the slot
+	SlotAnnotated      bool                                // Whether PreBind marked the pod with the consumed slot
+	PlacementAnnotated bool                                // Whether PreBind marked the pod with the hint placement
 }
 
-// Clone implements framework.StateData interface
+// Clone implements framework.StateData interface. The hint used to be shared with the clone, which was
+// safe only because slot updates always patch a freshly fetched hint; it is deep copied so that code
+// mutating a clone's hint or target weights never affects the original
 func (m *MultiObjectiveState) Clone() framework.StateData {
 	return &MultiObjectiveState{
-		TargetNode: m.TargetNode,
-		HasHint:    m.HasHint,
-		Hint:       m.Hint,
-		RSKey:      m.RSKey,
+		TargetNode:         m.TargetNode,
+		TargetWeights:      maps.Clone(m.TargetWeights),
+		HasHint:            m.HasHint,
+		Hint:               m.Hint.DeepCopy(),
+		SolutionIndex:      m.SolutionIndex,
+		RSKey:              m.RSKey,
+		SlotConsumed:       m.SlotConsumed,
+		ScheduledCount:     m.ScheduledCount,
+		SlotAnnotated:      m.SlotAnnotated,
+		PlacementAnnotated: m.PlacementAnnotated,
 	}
 }
 
+// hintState stores the scheduling hint looked up once per scheduling cycle, shared read-only by
+// Filter, PreScore and Score
+type hintState struct {
+	hint          *deschedulerv1alpha1.SchedulingHint     // nil when no usable hint was found
+	solutionIndex int                                     // The index of the hint solution selected for the pod
+	movement      *deschedulerv1alpha1.ReplicaSetMovement // nil when the solution has no movement for the pod's ReplicaSet
+}
+
+// Clone implements framework.StateData interface
+func (h *hintState) Clone() framework.StateData {
+	return h
+}
+
 // MultiObjectiveScheduler is a scheduler plugin that consumes hints from the descheduler
 type MultiObjectiveScheduler struct {
-	logger klog.Logger
-	handle framework.Handle
+	logger   klog.Logger
+	handle   framework.Handle
+	args     *config.MultiObjectiveArgs
+	client   versioned.Interface // Client for SchedulingHint custom resources, built once in New
+	rsLister appslisters.ReplicaSetLister
+	nsLister corelisters.NamespaceLister // Resolves the namespace selectors of pod anti-affinity terms
+	breaker  *hintLookupBreaker
+	clock    clock.Clock
+	stopCh   <-chan struct{} // Closed when the scheduler shuts down, to stop background work
+	// schedulerName is the name of the profile the plugin runs in, which pods it schedules select
+	schedulerName string
+	// active is set once the plugin runs its first scheduling cycle. kube-scheduler only schedules on the
+	// elected leader, while informers run on every replica
+	active       atomic.Bool
+	activateOnce sync.Once
+	// controlPlaneTaints are the parsed ControlPlaneTaints entries
+	controlPlaneTaints []controlPlaneTaint
+	// alternates holds the solutions recorded by PostFilter for the next scheduling cycle of pods
+	alternates *alternateSolutions
+	// slotReleases queues the slots of deleted pods, so the informer's delete handler never blocks on the API server
+	slotReleases workqueue.TypedInterface[slotRelease]
 }
 
+var _ framework.PreFilterPlugin = &MultiObjectiveScheduler{}
+var _ framework.FilterPlugin = &MultiObjectiveScheduler{}
+var _ framework.PostFilterPlugin = &MultiObjectiveScheduler{}
 var _ framework.PreScorePlugin = &MultiObjectiveScheduler{}
 var _ framework.ScorePlugin = &MultiObjectiveScheduler{}
+var _ framework.ReservePlugin = &MultiObjectiveScheduler{}
+var _ framework.PermitPlugin = &MultiObjectiveScheduler{}
+var _ framework.PreBindPlugin = &MultiObjectiveScheduler{}
 
 // NewScheduler builds the scheduler plugin
-func New(ctx context.Context, args runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+func New(ctx context.Context, obj runtime.Object, handle framework.Handle) (framework.Plugin, error) {
+	client, err := versioned.NewForConfig(handle.KubeConfig())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create clientset: %w", err)
+	}
+	return newWithClient(ctx, obj, handle, client)
+}
+
+// newWithClient builds the scheduler plugin on the given SchedulingHint clientset, which every
+// goroutine started here shares with the scheduling cycles
+func newWithClient(ctx context.Context, obj runtime.Object, handle framework.Handle, client versioned.Interface) (framework.Plugin, error) {
 	logger := klog.FromContext(ctx).WithName(Name)
 
-	return &MultiObjectiveScheduler{
-		logger: logger,
-		handle: handle,
-	}, nil
+	args, ok := obj.(*config.MultiObjectiveArgs)
+	if !ok {
+		return nil, fmt.Errorf("want args to be of type MultiObjectiveArgs, got %T", obj)
+	}
+
+	if args.SlotUpdateMaxRetries < 1 {
+		return nil, fmt.Errorf("slotUpdateMaxRetries must be at least 1, got %d", args.SlotUpdateMaxRetries)
+	}
+	if args.SlotConfirmationTimeoutSeconds < 0 {
+		return nil, fmt.Errorf("slotConfirmationTimeoutSeconds must not be negative, got %d", args.SlotConfirmationTimeoutSeconds)
+	}
+	if args.MaxInfluence < 1 || args.MaxInfluence > MaxNodeScore {
+		return nil, fmt.Errorf("maxInfluence must be between 1 and %d, got %d", MaxNodeScore, args.MaxInfluence)
+	}
+	controlPlaneTaints, err := parseControlPlaneTaints(args.ControlPlaneTaints)
+	if err != nil {
+		return nil, err
+	}
+	if args.HintLookupTimeoutMilliseconds < 0 {
+		return nil, fmt.Errorf("hintLookupTimeoutMilliseconds must not be negative, got %d", args.HintLookupTimeoutMilliseconds)
+	}
+
+	RegisterMetrics()
+
+	s := &MultiObjectiveScheduler{
+		logger:             logger,
+		handle:             handle,
+		args:               args,
+		client:             client,
+		rsLister:           handle.SharedInformerFactory().Apps().V1().ReplicaSets().Lister(),
+		nsLister:           handle.SharedInformerFactory().Core().V1().Namespaces().Lister(),
+		breaker:            newHintLookupBreaker(clock.RealClock{}),
+		clock:              clock.RealClock{},
+		stopCh:             ctx.Done(),
+		controlPlaneTaints: controlPlaneTaints,
+		alternates:         newAlternateSolutions(),
+		slotReleases: workqueue.NewTypedWithConfig(workqueue.TypedQueueConfig[slotRelease]{
+			Name: "multiobjective_slot_release",
+		}),
+	}
+	if p, ok := handle.(interface{ ProfileName() string }); ok {
+		s.schedulerName = p.ProfileName()
+	}
+	// A dry run never consumes slots, so it has none to release
+	if !args.DryRun {
+		s.addPodDeleteHandler()
+		go s.runSlotReleaseWorker(ctx)
+	}
+	return s, nil
 }
 
 // Name returns the plugin name
@@ -89,6 +217,140 @@ func (s *MultiObjectiveScheduler) Name() string {
 	return Name
 }
 
+// PreFilter implements the PreFilter extension point. It looks up the scheduling hint once per
+// scheduling cycle and stores it in the cycle state for Filter, PreScore and Score to reuse
+func (s *MultiObjectiveScheduler) PreFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod) (*framework.PreFilterResult, *framework.Status) {
+	s.activate(ctx)
+	hs := s.getHintState(ctx, state, pod)
+
+	// Filter has nothing to do unless it may exclude nodes based on the hint's movement for the pod
+	if !s.filtersNodes() || hs.movement == nil {
+		return nil, framework.NewStatus(framework.Skip)
+	}
+	return nil, nil
+}
+
+// PreFilterExtensions returns prefilter extensions
+func (s *MultiObjectiveScheduler) PreFilterExtensions() framework.PreFilterExtensions {
+	return nil
+}
+
+// Filter implements the Filter extension point. When a scheduling hint has a movement for the pod's
+// ReplicaSet, FilterNonTargetNodes only lets nodes with available slots in it pass, and
+// FilterExhaustedTargetNodes rejects target nodes without slots while other target nodes have some.
+// Strict hint mode rejects nodes like FilterNonTargetNodes, so a pod whose target nodes are infeasible or
+// used up waits as Unschedulable instead of falling back to default placement
+func (s *MultiObjectiveScheduler) Filter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if !s.filtersNodes() {
+		return nil
+	}
+	if nodeInfo.Node() == nil {
+		return framework.NewStatus(framework.Error, "node not found")
+	}
+
+	hs := s.getHintState(ctx, state, pod)
+	if hs.movement == nil {
+		return nil
+	}
+
+	nodeName := nodeInfo.Node().Name
+	if hs.movement.AvailableSlotsFor(nodeName) > 0 {
+		return nil
+	}
+	if s.args.FilterNonTargetNodes || s.args.StrictHint {
+		return framework.NewStatus(framework.Unschedulable,
+			fmt.Sprintf("node %s has no available slots for ReplicaSet %s/%s in scheduling hint %s",
+				nodeName, hs.movement.Namespace, hs.movement.ReplicaSetName, hs.hint.Name))
+	}
+	if s.args.FilterExhaustedTargetNodes && hs.movement.TargetCountFor(nodeName) > 0 && hasAvailableSlots(hs.movement) {
+		return framework.NewStatus(framework.Unschedulable,
+			fmt.Sprintf("node %s has used up its slots for ReplicaSet %s/%s in scheduling hint %s",
+				nodeName, hs.movement.Namespace, hs.movement.ReplicaSetName, hs.hint.Name))
+	}
+	return nil
+}
+
+// filtersNodes returns whether Filter may reject nodes based on the scheduling hint
+func (s *MultiObjectiveScheduler) filtersNodes() bool {
+	return (s.args.FilterNonTargetNodes || s.args.FilterExhaustedTargetNodes || s.args.StrictHint) && !s.args.DryRun
+}
+
+// getHintState returns the scheduling hint for the current cycle. The hint is normally looked up in
+// PreFilter; later extension points only fall back to looking it up when the cycle state lacks it
+func (s *MultiObjectiveScheduler) getHintState(ctx context.Context, state *framework.CycleState, pod *v1.Pod) *hintState {
+	if data, err := state.Read(hintStateKey); err == nil {
+		if hs, ok := data.(*hintState); ok {
+			return hs
+		}
+	}
+
+	hs := &hintState{}
+	defer state.Write(hintStateKey, hs)
+
+	// Skip the lookup entirely while hints are being invalidated faster than they can be used
+	if !s.breaker.Allow() {
+		s.logger.V(4).Info("Scheduling hint lookup disabled by circuit breaker - will use default scoring",
+			"pod", klog.KObj(pod))
+		return hs
+	}
+
+	hint, solution, err := s.getSchedulingHint(ctx)
+	s.breaker.Record(err == nil && hint != nil && solution != nil)
+	if err != nil || hint == nil || solution == nil {
+		s.logger.V(4).Info("No scheduling hint available - will use default scoring",
+			"pod", klog.KObj(pod), "error", err)
+		return hs
+	}
+
+	rsKey := s.getReplicaSetKey(pod)
+	hs.hint = hint
+	hs.solutionIndex = s.selectUsableSolutionIndex(pod, hint, rsKey)
+	hs.movement = findReplicaSetMovement(&hint.Spec.Solutions[hs.solutionIndex], rsKey)
+	return hs
+}
+
+// selectUsableSolutionIndex returns the index of the hint solution to place the pod with. Pods declaring an
+// objective preference may be placed according to another Pareto-optimal solution. A pod for which PostFilter
+// recorded another solution is placed according to it while it has slots left for the pod's ReplicaSet. When
+// the selected solution has no available slots left for the pod's ReplicaSet, the best ranked solution that
+// still has some is used. Solutions exceeding MaxWeightedScore are never selected
+func (s *MultiObjectiveScheduler) selectUsableSolutionIndex(pod *v1.Pod, hint *deschedulerv1alpha1.SchedulingHint, rsKey string) int {
+	selected := s.selectSolutionIndex(pod, hint)
+	movement := findReplicaSetMovement(&hint.Spec.Solutions[selected], rsKey)
+
+	if i, ok := s.alternates.take(pod.UID, hint.Name); ok && i < len(hint.Spec.Solutions) &&
+		hasAvailableSlots(findReplicaSetMovement(&hint.Spec.Solutions[i], rsKey)) {
+		s.logger.V(3).Info("Using the solution recorded by PostFilter",
+			"pod", klog.KObj(pod), "hint", hint.Name, "selectedSolution", selected, "solution", i)
+		return i
+	}
+
+	if hasAvailableSlots(movement) {
+		return selected
+	}
+	for _, i := range solutionsByRank(hint) {
+		if i != selected && s.solutionApplicable(&hint.Spec.Solutions[i]) && hasAvailableSlots(findReplicaSetMovement(&hint.Spec.Solutions[i], rsKey)) {
+			s.logger.V(3).Info("Selected solution has no available slots - falling back to a lower ranked solution",
+				"pod", klog.KObj(pod), "hint", hint.Name, "selectedSolution", selected, "solution", i)
+			return i
+		}
+	}
+	return selected
+}
+
+// hasAvailableSlots returns whether the movement has a target node with available slots left
+func hasAvailableSlots(movement *deschedulerv1alpha1.ReplicaSetMovement) bool {
+	if movement == nil {
+		return false
+	}
+	for nodeName, targetCount := range movement.TargetDistribution {
+		if targetCount > 0 && movement.AvailableSlotsFor(nodeName) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
 // PreScore implements the PreScore extension point
 func (s *MultiObjectiveScheduler) PreScore(ctx context.Context, state *framework.CycleState, pod *v1.Pod, filteredNodes []*framework.NodeInfo) *framework.Status {
 	// Get ReplicaSet key for this pod
@@ -101,34 +363,65 @@ func (s *MultiObjectiveScheduler) PreScore(ctx context.Context, state *framework
 		Hint:       nil,
 		RSKey:      rsKey,
 	}
-	s.logger.V(4).Info("available nodes beginning", "nodes", len(filteredNodes))
+	s.logger.V(5).Info("Scoring available nodes", "pod", klog.KObj(pod), "nodes", len(filteredNodes))
 
-	// Try to get scheduling hint and select target node
-	hint, solution, err := s.getSchedulingHint(ctx)
-	if err != nil || hint == nil || solution == nil {
-		s.logger.V(4).Info("No scheduling hint available - will use default scoring",
-			"pod", klog.KObj(pod), "error", err)
+	// Reuse the scheduling hint looked up in PreFilter
+	hs := s.getHintState(ctx, state, pod)
+	if hs.hint == nil {
 		// Store state with no hint - Score will return min scores
 		state.Write(stateKey, cycleState)
 		return nil
 	}
 
+	hint := hs.hint
+	cycleState.SolutionIndex = hs.solutionIndex
+	solution := &hint.Spec.Solutions[hs.solutionIndex]
+
+	s.selectTargetNode(pod, cycleState, hint, solution, filteredNodes)
+
+	// In dry run mode, only report the decision and leave the pod to default scoring
+	if s.args.DryRun {
+		s.logger.Info("Dry run: scheduling hint placement decision",
+			"pod", klog.KObj(pod), "hint", hint.Name, "solution", cycleState.SolutionIndex,
+			"replicaSet", rsKey, "targetNode", cycleState.TargetNode, "wouldReject", s.args.StrictHint && hs.movement != nil && cycleState.TargetNode == "")
+		state.Write(stateKey, &MultiObjectiveState{RSKey: rsKey})
+		return nil
+	}
+
+	// Store state for Score method to use
+	state.Write(stateKey, cycleState)
+	return nil
+}
+
+// selectTargetNode records the hint's target node for the pod in the cycle state. Pods without an
+// eligible target node are left to default scoring
+func (s *MultiObjectiveScheduler) selectTargetNode(pod *v1.Pod, cycleState *MultiObjectiveState, hint *deschedulerv1alpha1.SchedulingHint, solution *deschedulerv1alpha1.OptimizationSolution, filteredNodes []*framework.NodeInfo) {
+	rsKey := cycleState.RSKey
+
+	// Required anti-affinity is checked against the pods on every node, since a matching pod on a node
+	// filtered out for an unrelated reason still blocks its whole topology domain
+	allNodes, err := s.handle.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		s.logger.V(4).Info("Failed to list nodes - checking anti-affinity on filtered nodes only",
+			"pod", klog.KObj(pod), "error", err)
+		allNodes = filteredNodes
+	}
+	antiAffinityNodes := getAntiAffinityViolatingNodes(pod, allNodes, s.nsLister)
+
 	// Find the best target node for this ReplicaSet from the solution
-	targetNode := s.selectBestNode(solution, rsKey, filteredNodes)
+	targetNode, targetWeights := s.selectBestNode(pod, solution, rsKey, filteredNodes, antiAffinityNodes)
 	if targetNode != "" {
 		cycleState.TargetNode = targetNode
+		cycleState.TargetWeights = targetWeights
 		cycleState.HasHint = true
 		cycleState.Hint = hint
 		s.logger.V(3).Info("Selected target node from scheduling hint",
-			"pod", klog.KObj(pod), "targetNode", targetNode, "replicaSet", rsKey)
-	} else {
-		s.logger.V(4).Info("No suitable target node found in scheduling hint",
-			"pod", klog.KObj(pod), "replicaSet", rsKey)
+			"pod", klog.KObj(pod), "replicaSet", rsKey, "node", targetNode, "hint", hint.Name)
+		return
 	}
 
-	// Store state for Score method to use
-	state.Write(stateKey, cycleState)
-	return nil
+	s.logger.V(4).Info("No suitable target node found in scheduling hint",
+		"pod", klog.KObj(pod), "replicaSet", rsKey, "hint", hint.Name)
 }
 
 // Score implements the Score extension point
@@ -150,151 +443,529 @@ func (s *MultiObjectiveScheduler) Score(ctx context.Context, state *framework.Cy
 
 	// If we don't have a hint, use min score (let NodeResourcesFit take over)
 	if !cycleState.HasHint {
-		s.logger.V(4).Info("No scheduling hint available - using min score",
+		s.logger.V(5).Info("No scheduling hint available - using min score",
 			"pod", klog.KObj(pod), "node", nodeName)
 		return MinNodeScore, nil
 	}
 
-	// If this is the target node, try to consume a slot atomically
+	// The target node gets the max score; its slot is only consumed once the pod is reserved on it
 	if nodeName == cycleState.TargetNode {
-		// Try to consume a slot for this ReplicaSet on this node
-		consumed := s.tryConsumeSlot(ctx, cycleState.Hint, cycleState.RSKey, nodeName)
-
-		if consumed {
-			s.logger.V(3).Info("Successfully consumed slot - scoring target node with max score",
-				"pod", klog.KObj(pod), "node", nodeName, "replicaSet", cycleState.RSKey, "score", MaxNodeScore)
-			return MaxNodeScore, nil
-		} else {
-			s.logger.V(4).Info("Failed to consume slot on target node - using min score",
-				"pod", klog.KObj(pod), "node", nodeName, "replicaSet", cycleState.RSKey, "score", MinNodeScore)
-			return MinNodeScore, nil
-		}
+		s.logger.V(4).Info("Scoring target node with max score",
+			"pod", klog.KObj(pod), "replicaSet", cycleState.RSKey, "node", nodeName, "score", s.args.MaxInfluence)
+		return s.args.MaxInfluence, nil
 	}
 
 	// For all other nodes, give min score
-	s.logger.V(4).Info("Scoring non-target node with min score",
-		"pod", klog.KObj(pod), "node", nodeName, "targetNode", cycleState.TargetNode, "score", MinNodeScore)
+	s.logger.V(5).Info("Scoring non-target node with min score",
+		"pod", klog.KObj(pod), "replicaSet", cycleState.RSKey, "node", nodeName, "targetNode", cycleState.TargetNode, "score", MinNodeScore)
 	return MinNodeScore, nil
 }
 
-// ScoreExtensions returns score extensions (none needed)
+// ScoreExtensions returns score extensions
 func (s *MultiObjectiveScheduler) ScoreExtensions() framework.ScoreExtensions {
+	return s
+}
+
+// NormalizeScore spreads the scores of the hint's eligible target nodes proportionally to their target
+// distribution, so the selected target node keeps the max score and other target nodes score in between.
+// The max score is MaxInfluence, and all scores are clamped into [MinNodeScore, MaxInfluence] as a final
+// safety step
+func (s *MultiObjectiveScheduler) NormalizeScore(ctx context.Context, state *framework.CycleState, pod *v1.Pod, scores framework.NodeScoreList) *framework.Status {
+	maxScore := s.args.MaxInfluence
+	if cycleState := readCycleState(state); cycleState != nil && cycleState.HasHint {
+		maxWeight := 0
+		for _, weight := range cycleState.TargetWeights {
+			if weight > maxWeight {
+				maxWeight = weight
+			}
+		}
+		if maxWeight > 0 {
+			for i := range scores {
+				weight, ok := cycleState.TargetWeights[scores[i].Name]
+				if !ok {
+					continue
+				}
+				if scores[i].Name == cycleState.TargetNode {
+					scores[i].Score = maxScore
+					continue
+				}
+				// The selected node may have a smaller target count than other target nodes, which must not outscore it
+				scores[i].Score = min(MinNodeScore+int64(weight)*(maxScore-MinNodeScore)/int64(maxWeight), maxScore-1)
+			}
+		}
+	}
+
+	for i := range scores {
+		if scores[i].Score < MinNodeScore {
+			scores[i].Score = MinNodeScore
+		} else if scores[i].Score > maxScore {
+			scores[i].Score = maxScore
+		}
+	}
+	return nil
+}
+
+// Reserve implements the Reserve extension point. It consumes a slot in the scheduling hint when the
+// pod is reserved on a target node of the hint's movement for its ReplicaSet that has slots left. The
+// target is resolved from the hint looked up in PreFilter, since PreScore does not run when a single
+// node is feasible. A pod whose slot cannot be consumed is rejected so that it is rescheduled when Filter
+// confines pods to the hint's slots, and is otherwise placed without a slot like a pod scored by default
+func (s *MultiObjectiveScheduler) Reserve(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) *framework.Status {
+	if s.args.DryRun {
+		return nil
+	}
+	hs := s.getHintState(ctx, state, pod)
+	if hs.movement == nil || hs.movement.TargetCountFor(nodeName) <= 0 || hs.movement.AvailableSlotsFor(nodeName) <= 0 {
+		return nil
+	}
+
+	cycleState := readCycleState(state)
+	if cycleState == nil {
+		cycleState = &MultiObjectiveState{RSKey: s.getReplicaSetKey(pod)}
+		state.Write(stateKey, cycleState)
+	}
+	cycleState.TargetNode = nodeName
+	cycleState.HasHint = true
+	cycleState.Hint = hs.hint
+	cycleState.SolutionIndex = hs.solutionIndex
+
+	scheduledCount, consumed := s.tryConsumeSlot(ctx, cycleState.Hint, cycleState.SolutionIndex, cycleState.RSKey, nodeName)
+	if !consumed {
+		s.logger.V(4).Info("Failed to consume slot on target node",
+			"pod", klog.KObj(pod), "replicaSet", cycleState.RSKey, "node", nodeName, "hint", cycleState.Hint.Name)
+		if !s.filtersNodes() {
+			return nil
+		}
+		return framework.NewStatus(framework.Unschedulable,
+			fmt.Sprintf("failed to consume slot on node %s for ReplicaSet %s in scheduling hint %s", nodeName, cycleState.RSKey, cycleState.Hint.Name))
+	}
+	cycleState.SlotConsumed = true
+	cycleState.ScheduledCount = scheduledCount
 	return nil
 }
 
-// selectBestNode selects the best target node for a ReplicaSet from the scheduling hint solution
-func (s *MultiObjectiveScheduler) selectBestNode(solution *deschedulerv1alpha1.OptimizationSolution, rsKey string, filteredNodes []*framework.NodeInfo) string {
+// Unreserve implements the Unreserve extension point. It returns the slot consumed in Reserve to the
+// scheduling hint and unmarks the pod when a later extension point or binding fails
+func (s *MultiObjectiveScheduler) Unreserve(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) {
+	cycleState := readCycleState(state)
+	if cycleState == nil {
+		return
+	}
+
+	// The pod is scheduled again, possibly without the hint, so it must not keep this placement
+	if cycleState.PlacementAnnotated {
+		if err := s.clearPlacementAnnotations(ctx, pod); err != nil {
+			s.logger.V(3).Info("Failed to unmark pod with scheduling hint placement",
+				"pod", klog.KObj(pod), "error", err.Error())
+		} else {
+			cycleState.PlacementAnnotated = false
+		}
+	}
+
+	if !cycleState.SlotConsumed {
+		return
+	}
+
+	if !s.tryReleaseSlot(ctx, cycleState.Hint, cycleState.SolutionIndex, cycleState.RSKey, nodeName) {
+		s.logger.V(3).Info("Failed to release slot on unreserve",
+			"pod", klog.KObj(pod), "replicaSet", cycleState.RSKey, "node", nodeName, "hint", cycleState.Hint.Name)
+		return
+	}
+	cycleState.SlotConsumed = false
+
+	// Unmark the pod so deleting it does not release the slot a second time
+	if cycleState.SlotAnnotated {
+		if err := s.patchPodAnnotation(ctx, pod, ""); err != nil {
+			s.logger.V(3).Info("Failed to unmark pod with consumed slot",
+				"pod", klog.KObj(pod), "error", err.Error())
+			return
+		}
+		cycleState.SlotAnnotated = false
+	}
+}
+
+// readCycleState returns the state written by PreScore, or nil if it is missing
+func readCycleState(state *framework.CycleState) *MultiObjectiveState {
+	data, err := state.Read(stateKey)
+	if err != nil {
+		return nil
+	}
+	cycleState, ok := data.(*MultiObjectiveState)
+	if !ok {
+		return nil
+	}
+	return cycleState
+}
+
+// selectBestNode selects the best target node for a ReplicaSet from the scheduling hint solution, i.e. the
+// eligible target node with the most available slots relative to its target count. It also
+// returns the target counts of all eligible target nodes, i.e. those that are available and have slots.
+// Nodes in antiAffinityNodes would violate the pod's required anti-affinity and are never a valid target,
+// even if the hint prefers them
+func (s *MultiObjectiveScheduler) selectBestNode(pod *v1.Pod, solution *deschedulerv1alpha1.OptimizationSolution, rsKey string, filteredNodes []*framework.NodeInfo, antiAffinityNodes map[string]bool) (string, map[string]int) {
 	// Create a set of available nodes from filteredNodes
 	availableNodes := make(map[string]bool)
 	for _, nodeInfo := range filteredNodes {
-		if _, isControlPlane := nodeInfo.Node().Labels["node-role.kubernetes.io/control-plane"]; isControlPlane {
+		if s.isControlPlaneNode(nodeInfo.Node()) {
+			continue
+		}
+		if antiAffinityNodes[nodeInfo.Node().Name] {
+			s.logger.V(5).Info("Node excluded by required pod anti-affinity",
+				"pod", klog.KObj(pod), "replicaSet", rsKey, "node", nodeInfo.Node().Name)
 			continue
 		}
 		availableNodes[nodeInfo.Node().Name] = true
 	}
 
 	// Find the ReplicaSet movement in the solution
-	for _, movement := range solution.ReplicaSetMovements {
-		movementKey := fmt.Sprintf("%s/%s", movement.Namespace, movement.ReplicaSetName)
-		if movementKey == rsKey {
-			// Find the node with the highest target distribution that's also available
-			bestNode := ""
-			maxTarget := 0
-
-			for nodeName, targetCount := range movement.TargetDistribution {
-				s.logger.Info("checking distribution", "node", nodeName, "targetCount", targetCount)
-				// Check if this node is in the filtered list (passed scheduling constraints)
-				if !availableNodes[nodeName] {
-					s.logger.V(4).Info("node not available", "node", nodeName)
-					continue
-				}
+	movement := findReplicaSetMovement(solution, rsKey)
+	if movement == nil {
+		s.logger.V(4).Info("No movement found for ReplicaSet in solution", "pod", klog.KObj(pod), "replicaSet", rsKey)
+		return "", nil
+	}
 
-				// Check if this node has available slots
-				availableSlots := movement.AvailableSlots[nodeName]
-				s.logger.V(4).Info("slots on the node", "node", nodeName, "slots", availableSlots)
-				if availableSlots > 0 && targetCount > maxTarget {
-					bestNode = nodeName
-					maxTarget = targetCount
-				}
-			}
+	// Spread successive pods across the target nodes in proportion to the target distribution by
+	// picking the available node with the most remaining slots relative to its target count
+	bestNode := ""
+	bestSlots, bestTarget := 0, 0
+	targetWeights := make(map[string]int)
+
+	for nodeName, targetCount := range movement.TargetDistribution {
+		// Check if this node is in the filtered list (passed scheduling constraints)
+		if !availableNodes[nodeName] {
+			s.logger.V(5).Info("Target node not available",
+				"pod", klog.KObj(pod), "replicaSet", rsKey, "node", nodeName)
+			continue
+		}
 
-			s.logger.V(4).Info("Selected best node for ReplicaSet",
-				"replicaSet", rsKey, "bestNode", bestNode, "targetCount", maxTarget)
-			return bestNode
+		// Check if this node has available slots
+		availableSlots := movement.AvailableSlotsFor(nodeName)
+		s.logger.V(5).Info("Checking target node",
+			"pod", klog.KObj(pod), "replicaSet", rsKey, "node", nodeName, "targetCount", targetCount, "availableSlots", availableSlots)
+		if availableSlots <= 0 || targetCount <= 0 {
+			continue
+		}
+		targetWeights[nodeName] = targetCount
+		if bestNode == "" || hasMoreHeadroom(nodeName, availableSlots, targetCount, bestNode, bestSlots, bestTarget) {
+			bestNode = nodeName
+			bestSlots, bestTarget = availableSlots, targetCount
 		}
 	}
 
-	s.logger.V(4).Info("No movement found for ReplicaSet in solution", "replicaSet", rsKey)
-	return ""
+	s.logger.V(4).Info("Selected best node for ReplicaSet",
+		"pod", klog.KObj(pod), "replicaSet", rsKey, "node", bestNode, "targetCount", bestTarget, "availableSlots", bestSlots)
+	return bestNode, targetWeights
 }
 
-// getSchedulingHint fetches the appropriate scheduling hint for a pod
-func (s *MultiObjectiveScheduler) getSchedulingHint(ctx context.Context) (*deschedulerv1alpha1.SchedulingHint, *deschedulerv1alpha1.OptimizationSolution, error) {
-	// Get cluster state
-	nodes, err := s.handle.ClientSet().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to list nodes: %w", err)
+// hasMoreHeadroom returns whether node a has a larger share of its target count left in available slots
+// than node b. Ties go to the node with the larger target count, then to the first node by name, so
+// every scheduler replica picks the same target regardless of map iteration order
+func hasMoreHeadroom(a string, aSlots, aTarget int, b string, bSlots, bTarget int) bool {
+	if left, right := aSlots*bTarget, bSlots*aTarget; left != right {
+		return left > right
+	}
+	if aTarget != bTarget {
+		return aTarget > bTarget
 	}
+	return a < b
+}
 
-	// Get all ReplicaSets to calculate current cluster fingerprint based on desired state
-	replicaSets, err := s.handle.ClientSet().AppsV1().ReplicaSets("").List(ctx, metav1.ListOptions{})
+// getAntiAffinityViolatingNodes returns the set of nodes on which placing the pod would violate a
+// required pod anti-affinity term, either one of its own terms matching the pods already running in the
+// node's topology domain, or a term of such a pod matching the pod. nodes must hold every node of the
+// cluster, since a topology domain spans nodes the pod is not considered for
+func getAntiAffinityViolatingNodes(pod *v1.Pod, nodes []*framework.NodeInfo, nsLister corelisters.NamespaceLister) map[string]bool {
+	violating := make(map[string]bool)
+
+	podInfo, err := framework.NewPodInfo(pod)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to list ReplicaSets: %w", err)
+		return violating
 	}
 
-	// Calculate current cluster fingerprint based on ReplicaSet desired state
-	fingerprint := s.calculateClusterFingerprintFromReplicaSets(ctx, nodes.Items, replicaSets.Items)
+	// Namespace labels are looked up once per namespace, for the terms' namespace selectors
+	nsLabels := make(map[string]labels.Set)
+	getNamespaceLabels := func(namespace string) labels.Set {
+		if l, ok := nsLabels[namespace]; ok {
+			return l
+		}
+		var l labels.Set
+		if nsLister != nil {
+			if ns, err := nsLister.Get(namespace); err == nil {
+				l = labels.Set(ns.Labels)
+			}
+		}
+		nsLabels[namespace] = l
+		return l
+	}
 
-	// Get REST config for custom resource client
-	config, err := s.getRESTConfig()
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to get REST config: %w", err)
+	// Collect the topology domains that already contain a pod matching any of the pod's terms, or a pod
+	// whose terms match the pod
+	type topologyPair struct {
+		key   string
+		value string
+	}
+	blockedDomains := make(map[topologyPair]bool)
+	block := func(node *v1.Node, term *framework.AffinityTerm) {
+		if value, ok := node.Labels[term.TopologyKey]; ok {
+			blockedDomains[topologyPair{key: term.TopologyKey, value: value}] = true
+		}
+	}
+	for _, nodeInfo := range nodes {
+		node := nodeInfo.Node()
+		if node == nil {
+			continue
+		}
+		for i := range podInfo.RequiredAntiAffinityTerms {
+			term := &podInfo.RequiredAntiAffinityTerms[i]
+			for _, existing := range nodeInfo.Pods {
+				if term.Matches(existing.Pod, getNamespaceLabels(existing.Pod.Namespace)) {
+					block(node, term)
+					break
+				}
+			}
+		}
+		for _, existing := range nodeInfo.PodsWithRequiredAntiAffinity {
+			for i := range existing.RequiredAntiAffinityTerms {
+				term := &existing.RequiredAntiAffinityTerms[i]
+				if term.Matches(pod, getNamespaceLabels(pod.Namespace)) {
+					block(node, term)
+				}
+			}
+		}
+	}
+
+	if len(blockedDomains) == 0 {
+		return violating
+	}
+
+	// Any node within a blocked topology domain would violate the anti-affinity
+	for _, nodeInfo := range nodes {
+		node := nodeInfo.Node()
+		if node == nil {
+			continue
+		}
+		for pair := range blockedDomains {
+			if value, ok := node.Labels[pair.key]; ok && value == pair.value {
+				violating[node.Name] = true
+				break
+			}
+		}
 	}
 
-	// Create clientset
-	clientset, err := versioned.NewForConfig(config)
+	return violating
+}
+
+// getSchedulingHint fetches the appropriate scheduling hint for a pod and records the lookup metrics
+func (s *MultiObjectiveScheduler) getSchedulingHint(ctx context.Context) (*deschedulerv1alpha1.SchedulingHint, *deschedulerv1alpha1.OptimizationSolution, error) {
+	start := time.Now()
+	hint, solution, err := s.fetchSchedulingHint(ctx)
+	hintLookupDuration.Observe(time.Since(start).Seconds())
+
+	if err == nil && hint != nil && solution != nil {
+		hintLookupTotal.WithLabelValues(hintLookupHit).Inc()
+	} else {
+		hintLookupTotal.WithLabelValues(hintLookupMiss).Inc()
+	}
+	return hint, solution, err
+}
+
+// fetchSchedulingHint fetches the scheduling hint matching the current cluster fingerprint
+func (s *MultiObjectiveScheduler) fetchSchedulingHint(ctx context.Context) (*deschedulerv1alpha1.SchedulingHint, *deschedulerv1alpha1.OptimizationSolution, error) {
+	// Calculate current cluster fingerprint based on ReplicaSet desired state
+	fingerprint, err := s.getClusterFingerprint()
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to create clientset: %w", err)
+		return nil, nil, err
 	}
 
-	s.logger.Info("generating the hint name", "fingerprint", fingerprint)
 	// Try to get hint for exact cluster fingerprint
 	hintName := s.generateHintName(fingerprint)
-	hint, err := clientset.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hintName, metav1.GetOptions{})
+	s.logger.V(5).Info("Looking up scheduling hint", "hint", hintName, "fingerprint", fingerprint)
+	lookupCtx := ctx
+	if s.args.HintLookupTimeoutMilliseconds > 0 {
+		var cancel context.CancelFunc
+		lookupCtx, cancel = context.WithTimeout(ctx, time.Duration(s.args.HintLookupTimeoutMilliseconds)*time.Millisecond)
+		defer cancel()
+	}
+	hint, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(lookupCtx, hintName, metav1.GetOptions{})
+	if err != nil && lookupCtx.Err() != nil {
+		// A slow API server counts as a failed lookup, so the circuit breaker stops waiting on it
+		return nil, nil, fmt.Errorf("scheduling hint lookup for %s timed out: %w", hintName, err)
+	}
 	if err != nil {
 		s.logger.V(4).Info("No scheduling hint found for current cluster state",
-			"hintName", hintName, "fingerprint", fingerprint, "error", err.Error())
+			"hint", hintName, "fingerprint", fingerprint, "error", err.Error())
 		return nil, nil, nil // Return nil without error to trigger fallback to default scoring
 	}
 
+	// Never place pods according to a hint that has outlived the cluster state it was computed for
+	if reason := hintUnusableReason(hint, s.clock.Now()); reason != "" {
+		s.logger.V(3).Info("Skipping unusable scheduling hint - will use default scoring",
+			"hint", hint.Name, "fingerprint", fingerprint, "reason", reason)
+		return nil, nil, nil
+	}
+
+	// Never place pods according to a hint that references nodes which are gone, or that was computed
+	// without nodes which have since joined, e.g. because the cluster changed after the fingerprint was taken
+	currentNodes, workerNodes, err := s.getCurrentNodeNames()
+	if err != nil {
+		return nil, nil, err
+	}
+	missing := missingHintNodes(hint, currentNodes)
+	unrecorded := unrecordedHintNodes(hint, workerNodes)
+	if len(missing) > 0 || len(unrecorded) > 0 {
+		hintNodeMismatchTotal.Inc()
+		s.logger.V(3).Info("Skipping scheduling hint that does not match the current cluster nodes - will use default scoring",
+			"hint", hint.Name, "fingerprint", fingerprint, "hintFingerprint", hint.Spec.ClusterFingerprint,
+			"missingNodes", missing, "unrecordedNodes", unrecorded)
+		return nil, nil, nil
+	}
+
 	// Get the top solution (first one is best)
 	if len(hint.Spec.Solutions) == 0 {
 		return nil, nil, fmt.Errorf("no solutions in scheduling hint")
 	}
 
-	topSolution := &hint.Spec.Solutions[0]
+	// Never perturb placement for a hint none of whose solutions is worth applying
+	applicable := s.applicableSolutions(hint)
+	if len(applicable) == 0 {
+		s.logger.V(3).Info("Skipping scheduling hint with insufficient improvement - will use default scoring",
+			"hint", hint.Name, "fingerprint", fingerprint, "topSolutionScore", hint.Spec.Solutions[0].WeightedScore,
+			"maxWeightedScore", *s.args.MaxWeightedScore)
+		return nil, nil, nil
+	}
+	topSolution := &hint.Spec.Solutions[applicable[0]]
 
 	s.logger.V(3).Info("Found scheduling hint",
 		"hint", hint.Name,
 		"fingerprint", fingerprint,
 		"solutions", len(hint.Spec.Solutions),
 		"topSolutionScore", topSolution.WeightedScore,
+		"topSolutionObjectives", topSolution.AllObjectives(),
 		"age", time.Since(hint.CreationTimestamp.Time).Round(time.Second))
 
 	return hint, topSolution, nil
 }
 
-// calculateClusterFingerprintFromReplicaSets calculates fingerprint based on ReplicaSet desired state
-// isSystemNamespace checks if a namespace should be excluded from fingerprint calculation
-func isSystemNamespace(namespace string) bool {
-	systemNamespaces := []string{
-		"kube-system",
-		"kube-public",
-		"kube-node-lease",
-		"local-path-storage",
+// solutionApplicable returns whether the solution improves the cluster enough to be applied, i.e. its
+// weighted score does not exceed MaxWeightedScore
+func (s *MultiObjectiveScheduler) solutionApplicable(solution *deschedulerv1alpha1.OptimizationSolution) bool {
+	return s.args.MaxWeightedScore == nil || solution.WeightedScore <= *s.args.MaxWeightedScore
+}
+
+// applicableSolutions returns the indexes of the hint's solutions that are worth applying, in the hint's order
+func (s *MultiObjectiveScheduler) applicableSolutions(hint *deschedulerv1alpha1.SchedulingHint) []int {
+	var applicable []int
+	for i := range hint.Spec.Solutions {
+		if s.solutionApplicable(&hint.Spec.Solutions[i]) {
+			applicable = append(applicable, i)
+		}
+	}
+	return applicable
+}
+
+// hintUnusableReason returns why the hint must not be used at the given time, or "" if it is usable
+func hintUnusableReason(hint *deschedulerv1alpha1.SchedulingHint, now time.Time) string {
+	if hint.Status.Phase == deschedulerv1alpha1.SchedulingHintPhaseExpired {
+		return "hint phase is Expired"
+	}
+	if hint.Spec.ExpirationTime != nil && !now.Before(hint.Spec.ExpirationTime.Time) {
+		return fmt.Sprintf("hint expired at %s", hint.Spec.ExpirationTime.UTC().Format(time.RFC3339))
+	}
+	if err := deschedulerv1alpha1.ValidateSchedulingHint(hint); err != nil {
+		return fmt.Sprintf("hint is invalid: %v", err)
+	}
+	return ""
+}
+
+// getCurrentNodeNames returns the names of all nodes in the scheduler's snapshot, and the sorted names of
+// its worker nodes, i.e. those the descheduler computes hints for
+func (s *MultiObjectiveScheduler) getCurrentNodeNames() (map[string]bool, []string, error) {
+	nodeInfos, err := s.handle.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+	nodeNames := make(map[string]bool, len(nodeInfos))
+	workerNodes := make([]string, 0, len(nodeInfos))
+	for _, nodeInfo := range nodeInfos {
+		if nodeInfo.Node() == nil {
+			continue
+		}
+		nodeNames[nodeInfo.Node().Name] = true
+		if !s.isControlPlaneNode(nodeInfo.Node()) {
+			workerNodes = append(workerNodes, nodeInfo.Node().Name)
+		}
+	}
+	sort.Strings(workerNodes)
+	return nodeNames, workerNodes, nil
+}
+
+// unrecordedHintNodes returns the worker nodes missing from the hint's ClusterNodes. Hints that do not
+// record their cluster nodes are not checked
+func unrecordedHintNodes(hint *deschedulerv1alpha1.SchedulingHint, workerNodes []string) []string {
+	if len(hint.Spec.ClusterNodes) == 0 {
+		return nil
+	}
+	recorded := sets.New(hint.Spec.ClusterNodes...)
+	var unrecorded []string
+	for _, nodeName := range workerNodes {
+		if !recorded.Has(nodeName) {
+			unrecorded = append(unrecorded, nodeName)
+		}
+	}
+	return unrecorded
+}
+
+// missingHintNodes returns the sorted names of nodes recorded in the hint's ClusterNodes, or targeted by
+// or holding slots in any of its solutions, that no longer exist
+func missingHintNodes(hint *deschedulerv1alpha1.SchedulingHint, currentNodes map[string]bool) []string {
+	missing := sets.New[string]()
+	for _, nodeName := range hint.Spec.ClusterNodes {
+		if !currentNodes[nodeName] {
+			missing.Insert(nodeName)
+		}
+	}
+	for _, solution := range hint.Spec.Solutions {
+		for _, movement := range solution.ReplicaSetMovements {
+			for nodeName, targetCount := range movement.TargetDistribution {
+				if targetCount > 0 && !currentNodes[nodeName] {
+					missing.Insert(nodeName)
+				}
+			}
+			for nodeName, slots := range movement.AvailableSlots {
+				if slots > 0 && !currentNodes[nodeName] {
+					missing.Insert(nodeName)
+				}
+			}
+		}
+	}
+	return sets.List(missing)
+}
+
+// getClusterFingerprint calculates the cluster fingerprint from the scheduler's cached nodes and ReplicaSets
+func (s *MultiObjectiveScheduler) getClusterFingerprint() (string, error) {
+	nodeInfos, err := s.handle.SnapshotSharedLister().NodeInfos().List()
+	if err != nil {
+		return "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+	nodes := make([]*v1.Node, 0, len(nodeInfos))
+	for _, nodeInfo := range nodeInfos {
+		if nodeInfo.Node() != nil {
+			nodes = append(nodes, nodeInfo.Node())
+		}
+	}
+
+	// Get all ReplicaSets to calculate current cluster fingerprint based on desired state
+	replicaSets, err := s.rsLister.List(labels.Everything())
+	if err != nil {
+		return "", fmt.Errorf("failed to list ReplicaSets: %w", err)
 	}
 
-	for _, sysNs := range systemNamespaces {
+	return s.calculateClusterFingerprintFromReplicaSets(nodes, replicaSets), nil
+}
+
+// isSystemNamespace checks if a namespace should be excluded from fingerprint calculation
+func (s *MultiObjectiveScheduler) isSystemNamespace(namespace string) bool {
+	for _, sysNs := range s.args.SystemNamespaces {
 		if namespace == sysNs {
 			return true
 		}
@@ -302,12 +973,57 @@ func isSystemNamespace(namespace string) bool {
 	return false
 }
 
-func (s *MultiObjectiveScheduler) calculateClusterFingerprintFromReplicaSets(ctx context.Context, nodes []v1.Node, replicaSets []appsv1.ReplicaSet) string {
+// isControlPlaneNode checks if a node carries any of the configured control-plane labels or taints
+func (s *MultiObjectiveScheduler) isControlPlaneNode(node *v1.Node) bool {
+	for _, label := range s.args.ControlPlaneLabels {
+		if _, ok := node.Labels[label]; ok {
+			return true
+		}
+	}
+	for _, cpTaint := range s.controlPlaneTaints {
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == cpTaint.key && (cpTaint.effect == "" || taint.Effect == cpTaint.effect) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// controlPlaneTaint is a taint key, with an optional effect, marking control-plane nodes
+type controlPlaneTaint struct {
+	key    string
+	effect v1.TaintEffect
+}
+
+// parseControlPlaneTaints parses the ControlPlaneTaints entries, each a taint key with an optional ":<effect>"
+func parseControlPlaneTaints(entries []string) ([]controlPlaneTaint, error) {
+	taints := make([]controlPlaneTaint, 0, len(entries))
+	for _, entry := range entries {
+		key, effect, hasEffect := strings.Cut(entry, ":")
+		if key == "" {
+			return nil, fmt.Errorf("missing taint key in controlPlaneTaints entry %q", entry)
+		}
+		taint := controlPlaneTaint{key: key}
+		if hasEffect {
+			switch taint.effect = v1.TaintEffect(effect); taint.effect {
+			case v1.TaintEffectNoSchedule, v1.TaintEffectPreferNoSchedule, v1.TaintEffectNoExecute:
+			default:
+				return nil, fmt.Errorf("invalid taint effect %q in controlPlaneTaints entry %q", effect, entry)
+			}
+		}
+		taints = append(taints, taint)
+	}
+	return taints, nil
+}
+
+// calculateClusterFingerprintFromReplicaSets calculates fingerprint based on ReplicaSet desired state
+func (s *MultiObjectiveScheduler) calculateClusterFingerprintFromReplicaSets(nodes []*v1.Node, replicaSets []*appsv1.ReplicaSet) string {
 	// Filter to worker nodes only (same as descheduler)
 
-	workerNodes := []v1.Node{}
+	workerNodes := []*v1.Node{}
 	for _, node := range nodes {
-		if _, isControlPlane := node.Labels["node-role.kubernetes.io/control-plane"]; !isControlPlane {
+		if !s.isControlPlaneNode(node) {
 			workerNodes = append(workerNodes, node)
 		}
 	}
@@ -315,7 +1031,7 @@ func (s *MultiObjectiveScheduler) calculateClusterFingerprintFromReplicaSets(ctx
 	// Create node names list
 	nodeNames := make([]string, len(workerNodes))
 	for i, node := range workerNodes {
-		nodeNames[i] = node.Name
+		nodeNames[i] = s.nodeFingerprintEntry(node)
 	}
 	sort.Strings(nodeNames)
 
@@ -324,7 +1040,7 @@ func (s *MultiObjectiveScheduler) calculateClusterFingerprintFromReplicaSets(ctx
 
 	for _, rs := range replicaSets {
 		// Skip system namespaces to match descheduler behavior
-		if isSystemNamespace(rs.Namespace) {
+		if s.isSystemNamespace(rs.Namespace) {
 			continue
 		}
 
@@ -349,16 +1065,39 @@ func (s *MultiObjectiveScheduler) calculateClusterFingerprintFromReplicaSets(ctx
 		strings.Join(nodeNames, ","),
 		strings.Join(replicaSetSpecs, ";"))
 
-	s.logger.Info("the cluster spec", "spec", clusterSpec)
 	// Return hash for compact storage
 	hash := sha256.Sum256([]byte(clusterSpec))
-	return fmt.Sprintf("%x", hash)[:16]
+	fingerprint := fmt.Sprintf("%x", hash)[:16]
+	s.logger.V(5).Info("Computed cluster fingerprint", "fingerprint", fingerprint, "clusterSpec", clusterSpec)
+	return fingerprint
+}
+
+// nodeFingerprintEntry returns the node's entry in the cluster fingerprint. By default it is the node
+// name; the opt-in inputs are appended as name{cpu=<milliCPU>,memory=<bytes>,<label>=<value>,...}
+func (s *MultiObjectiveScheduler) nodeFingerprintEntry(node *v1.Node) string {
+	var inputs []string
+	if s.args.FingerprintNodeResources {
+		inputs = append(inputs,
+			fmt.Sprintf("cpu=%d", node.Status.Allocatable.Cpu().MilliValue()),
+			fmt.Sprintf("memory=%d", node.Status.Allocatable.Memory().Value()))
+	}
+
+	labelKeys := append([]string(nil), s.args.FingerprintNodeLabels...)
+	sort.Strings(labelKeys)
+	for _, key := range labelKeys {
+		inputs = append(inputs, fmt.Sprintf("%s=%s", key, node.Labels[key]))
+	}
+
+	if len(inputs) == 0 {
+		return node.Name
+	}
+	return fmt.Sprintf("%s{%s}", node.Name, strings.Join(inputs, ","))
 }
 
 // isPodEligible checks if a pod should be considered (same logic as descheduler)
 func (s *MultiObjectiveScheduler) isPodEligible(pod *v1.Pod) bool {
-	// Exclude kube-system namespace pods
-	if pod.Namespace == "kube-system" {
+	// Exclude system namespace pods
+	if s.isSystemNamespace(pod.Namespace) {
 		return false
 	}
 
@@ -387,131 +1126,222 @@ func (s *MultiObjectiveScheduler) getReplicaSetKey(pod *v1.Pod) string {
 	return fmt.Sprintf("%s/unknown", pod.Namespace)
 }
 
-// getAvailableSlotsForReplicaSet gets available slots for a ReplicaSet on a specific node
-func (s *MultiObjectiveScheduler) getAvailableSlotsForReplicaSet(solution *deschedulerv1alpha1.OptimizationSolution, rsKey, nodeName string) int {
-	for _, rsMovement := range solution.ReplicaSetMovements {
-		solutionRSKey := fmt.Sprintf("%s/%s", rsMovement.Namespace, rsMovement.ReplicaSetName)
-		if solutionRSKey == rsKey {
-			if slots, exists := rsMovement.AvailableSlots[nodeName]; exists {
-				return slots
-			}
+// findReplicaSetMovement returns the movement for a ReplicaSet in the solution, or nil if the solution has none
+func findReplicaSetMovement(solution *deschedulerv1alpha1.OptimizationSolution, rsKey string) *deschedulerv1alpha1.ReplicaSetMovement {
+	for i := range solution.ReplicaSetMovements {
+		movement := &solution.ReplicaSetMovements[i]
+		if fmt.Sprintf("%s/%s", movement.Namespace, movement.ReplicaSetName) == rsKey {
+			return movement
 		}
 	}
-	return 0
+	return nil
 }
 
-// tryConsumeSlot attempts to opportunistically consume a scheduling slot with retry
-func (s *MultiObjectiveScheduler) tryConsumeSlot(ctx context.Context, hint *deschedulerv1alpha1.SchedulingHint, rsKey, nodeName string) bool {
-	config, err := s.getRESTConfig()
-	if err != nil {
-		s.logger.V(3).Info("Cannot get REST config for slot consumption", "error", err.Error())
-		return false
-	}
+// getAvailableSlotsForReplicaSet gets available slots for a ReplicaSet on a specific node
+func (s *MultiObjectiveScheduler) getAvailableSlotsForReplicaSet(solution *deschedulerv1alpha1.OptimizationSolution, rsKey, nodeName string) int {
+	return findReplicaSetMovement(solution, rsKey).AvailableSlotsFor(nodeName)
+}
 
-	clientset, err := versioned.NewForConfig(config)
-	if err != nil {
-		s.logger.V(3).Info("Cannot create clientset for slot consumption", "error", err.Error())
-		return false
+// tryConsumeSlot attempts to opportunistically consume a scheduling slot with retry. On success it
+// returns the node's resulting ScheduledCount in the hint solution
+func (s *MultiObjectiveScheduler) tryConsumeSlot(ctx context.Context, hint *deschedulerv1alpha1.SchedulingHint, solutionIndex int, rsKey, nodeName string) (int, bool) {
+	return s.patchSlot(ctx, hint, solutionIndex, rsKey, nodeName, true)
+}
+
+// tryReleaseSlot returns a slot consumed by tryConsumeSlot to the scheduling hint with retry
+func (s *MultiObjectiveScheduler) tryReleaseSlot(ctx context.Context, hint *deschedulerv1alpha1.SchedulingHint, solutionIndex int, rsKey, nodeName string) bool {
+	_, released := s.patchSlot(ctx, hint, solutionIndex, rsKey, nodeName, false)
+	return released
+}
+
+// jsonPatchOperation is a single RFC 6902 JSON patch operation
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// jsonPointerEscaper escapes map keys for use as RFC 6901 JSON pointer tokens
+var jsonPointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// patchSlot consumes or releases a slot for a ReplicaSet on a node in the given hint solution. Only the
+// affected AvailableSlots and ScheduledCount entries are patched, with the fetched resourceVersion as a
+// precondition so a concurrent update makes the patch fail with a conflict and retry on a fresh fetch.
+// On success it returns the node's ScheduledCount written by the patch
+func (s *MultiObjectiveScheduler) patchSlot(ctx context.Context, hint *deschedulerv1alpha1.SchedulingHint, solutionIndex int, rsKey, nodeName string, consume bool) (int, bool) {
+	backoff := wait.Backoff{
+		Duration: slotUpdateInitialBackoff,
+		Factor:   slotUpdateBackoffFactor,
+		Jitter:   slotUpdateBackoffJitter,
+		Steps:    int(s.args.SlotUpdateMaxRetries),
 	}
+	for attempt := 1; attempt <= int(s.args.SlotUpdateMaxRetries); attempt++ {
+		if attempt > 1 {
+			if ctx.Err() != nil {
+				return 0, false
+			}
+			s.clock.Sleep(backoff.Step())
+		}
 
-	// Retry up to 3 times with fresh fetches
-	maxRetries := 3
-	for attempt := 1; attempt <= maxRetries; attempt++ {
 		// Get fresh hint to avoid conflicts
-		freshHint, err := clientset.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
+		freshHint, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
 		if err != nil {
-			s.logger.V(3).Info("Cannot fetch fresh hint for slot consumption",
-				"attempt", attempt, "error", err.Error())
-			if attempt == maxRetries {
-				return false
-			}
+			s.logger.V(3).Info("Cannot fetch fresh hint for slot update",
+				"hint", hint.Name, "replicaSet", rsKey, "node", nodeName, "attempt", attempt, "error", err.Error())
+			recordSlotConsume(consume, slotConsumeError)
 			continue
 		}
 
-		// Find and update the ReplicaSet movement in the top solution only
-		if len(freshHint.Spec.Solutions) == 0 {
-			s.logger.V(3).Info("No solutions in fresh hint", "attempt", attempt)
-			return false
-		}
-
-		topSolution := &freshHint.Spec.Solutions[0]
-		for i := range topSolution.ReplicaSetMovements {
-			rsMovement := &topSolution.ReplicaSetMovements[i]
-			solutionRSKey := fmt.Sprintf("%s/%s", rsMovement.Namespace, rsMovement.ReplicaSetName)
-
-			if solutionRSKey == rsKey {
-				// Check if slot is still available
-				if rsMovement.AvailableSlots[nodeName] > 0 {
-					// Consume the slot
-					rsMovement.AvailableSlots[nodeName]--
-					if rsMovement.ScheduledCount == nil {
-						rsMovement.ScheduledCount = make(map[string]int)
-					}
-					rsMovement.ScheduledCount[nodeName]++
-
-					// Update the hint
-					_, err = clientset.DeschedulerV1alpha1().SchedulingHints().Update(ctx, freshHint, metav1.UpdateOptions{})
-					if err != nil {
-						s.logger.V(3).Info("Failed to update hint after slot consumption",
-							"attempt", attempt, "error", err.Error())
-						if attempt == maxRetries {
-							return false
-						}
-						continue // Retry with fresh fetch
-					}
-
-					s.logger.V(1).Info("Successfully consumed scheduling slot",
-						"replicaSet", rsKey,
-						"node", nodeName,
-						"remainingSlots", rsMovement.AvailableSlots[nodeName],
-						"scheduledCount", rsMovement.ScheduledCount[nodeName],
-						"hint", hint.Name,
-						"attempt", attempt)
-
-					return true
-				} else {
-					// No slots available
-					s.logger.V(3).Info("No slots available on fresh check",
-						"attempt", attempt,
-						"replicaSet", rsKey,
-						"node", nodeName,
-						"availableSlots", rsMovement.AvailableSlots[nodeName])
-					return false
-				}
+		// Find the ReplicaSet movement in the solution used for the pod only
+		if len(freshHint.Spec.Solutions) <= solutionIndex {
+			s.logger.V(3).Info("Solution missing in fresh hint",
+				"hint", hint.Name, "replicaSet", rsKey, "node", nodeName, "attempt", attempt, "solution", solutionIndex)
+			recordSlotConsume(consume, slotConsumeEmpty)
+			return 0, false
+		}
+		movementIndex := -1
+		for i, rsMovement := range freshHint.Spec.Solutions[solutionIndex].ReplicaSetMovements {
+			if fmt.Sprintf("%s/%s", rsMovement.Namespace, rsMovement.ReplicaSetName) == rsKey {
+				movementIndex = i
+				break
 			}
 		}
+		if movementIndex < 0 {
+			s.logger.V(3).Info("ReplicaSet not found in solution",
+				"hint", hint.Name, "replicaSet", rsKey, "node", nodeName, "attempt", attempt)
+			recordSlotConsume(consume, slotConsumeEmpty)
+			return 0, false
+		}
+		rsMovement := freshHint.Spec.Solutions[solutionIndex].ReplicaSetMovements[movementIndex]
+
+		availableSlots := rsMovement.AvailableSlotsFor(nodeName)
+		scheduledCount := rsMovement.ScheduledCountFor(nodeName)
+		if consume && availableSlots <= 0 {
+			s.logger.V(3).Info("No slots available on fresh check",
+				"hint", hint.Name, "replicaSet", rsKey, "node", nodeName, "attempt", attempt, "availableSlots", availableSlots)
+			recordSlotConsume(consume, slotConsumeEmpty)
+			return 0, false
+		}
+		if !consume && scheduledCount <= 0 {
+			s.logger.V(3).Info("No consumed slot to release",
+				"hint", hint.Name, "replicaSet", rsKey, "node", nodeName, "attempt", attempt, "scheduledCount", scheduledCount)
+			return 0, false
+		}
+		if consume {
+			availableSlots--
+			scheduledCount++
+		} else {
+			availableSlots++
+			scheduledCount--
+		}
 
-		// ReplicaSet not found in solution
-		s.logger.V(3).Info("ReplicaSet not found in solution",
-			"attempt", attempt, "replicaSet", rsKey)
-		return false
+		patch, err := slotPatch(freshHint.ResourceVersion, solutionIndex, movementIndex, &rsMovement, nodeName, availableSlots, scheduledCount)
+		if err != nil {
+			s.logger.Error(err, "Failed to build slot patch", "hint", hint.Name, "replicaSet", rsKey, "node", nodeName)
+			recordSlotConsume(consume, slotConsumeError)
+			return 0, false
+		}
+		patchedHint, err := s.client.DeschedulerV1alpha1().SchedulingHints().Patch(ctx, hint.Name, types.JSONPatchType, patch, metav1.PatchOptions{})
+		if apierrors.IsConflict(err) {
+			s.logger.V(3).Info("Conflicting hint update during slot update - retrying",
+				"hint", hint.Name, "replicaSet", rsKey, "node", nodeName, "attempt", attempt)
+			recordSlotConsume(consume, slotConsumeConflict)
+			continue // Retry with fresh fetch
+		}
+		if err != nil {
+			s.logger.V(3).Info("Failed to patch hint for slot update",
+				"hint", hint.Name, "replicaSet", rsKey, "node", nodeName, "attempt", attempt, "error", err.Error())
+			recordSlotConsume(consume, slotConsumeError)
+			return 0, false
+		}
+
+		s.logger.V(3).Info("Updated scheduling slot",
+			"hint", hint.Name,
+			"replicaSet", rsKey,
+			"node", nodeName,
+			"consumed", consume,
+			"remainingSlots", availableSlots,
+			"scheduledCount", scheduledCount,
+			"attempt", attempt)
+		recordSlotConsume(consume, slotConsumeSuccess)
+		s.updateRemainingSlots(ctx, s.client, patchedHint)
+		return scheduledCount, true
 	}
 
-	return false
+	return 0, false
 }
 
-// generateHintName generates hint name from fingerprint (same as descheduler)
-func (s *MultiObjectiveScheduler) generateHintName(fingerprint string) string {
-	return fmt.Sprintf("multiobjective-hints-%s", fingerprint)
+// recordSlotConsume counts the outcome of a slot consumption attempt; slot releases are not counted
+func recordSlotConsume(consume bool, result string) {
+	if consume {
+		slotConsumeTotal.WithLabelValues(result).Inc()
+	}
 }
 
-// getRESTConfig gets the REST config for creating custom resource clients
-func (s *MultiObjectiveScheduler) getRESTConfig() (*rest.Config, error) {
-	// Try in-cluster config first
-	config, err := rest.InClusterConfig()
-	if err == nil {
-		return config, nil
-	}
+// slotPatch builds the JSON patch setting a node's slot counters for a movement of a solution,
+// conditioned on the hint still being at the given resourceVersion
+func slotPatch(resourceVersion string, solutionIndex, movementIndex int, rsMovement *deschedulerv1alpha1.ReplicaSetMovement, nodeName string, availableSlots, scheduledCount int) ([]byte, error) {
+	return preconditionedPatch(resourceVersion, slotOperations(solutionIndex, movementIndex, rsMovement, nodeName, availableSlots, scheduledCount))
+}
 
-	// Fallback to kubeconfig
-	s.logger.V(2).Info("In-cluster config not available, trying kubeconfig", "error", err.Error())
+// preconditionedPatch builds the JSON patch applying the operations, conditioned on the hint still being at
+// the given resourceVersion
+func preconditionedPatch(resourceVersion string, operations []jsonPatchOperation) ([]byte, error) {
+	return json.Marshal(append([]jsonPatchOperation{
+		{Op: "replace", Path: "/metadata/resourceVersion", Value: resourceVersion},
+	}, operations...))
+}
+
+// slotOperations returns the JSON patch operations setting a node's slot counters for a movement of a solution.
+// A missing slot map is added as a whole, since JSON patch cannot add a key to a map that does not exist
+func slotOperations(solutionIndex, movementIndex int, rsMovement *deschedulerv1alpha1.ReplicaSetMovement, nodeName string, availableSlots, scheduledCount int) []jsonPatchOperation {
+	movementPath := fmt.Sprintf("/spec/solutions/%d/replicaSetMovements/%d", solutionIndex, movementIndex)
+	node := jsonPointerEscaper.Replace(nodeName)
 
-	// Try default kubeconfig locations
-	kubeconfig := clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
-	config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	var operations []jsonPatchOperation
+	if rsMovement.AvailableSlots == nil {
+		operations = append(operations, jsonPatchOperation{Op: "add", Path: movementPath + "/availableSlots", Value: map[string]int{nodeName: availableSlots}})
+	} else {
+		operations = append(operations, jsonPatchOperation{Op: "add", Path: movementPath + "/availableSlots/" + node, Value: availableSlots})
+	}
+	if rsMovement.ScheduledCount == nil {
+		operations = append(operations, jsonPatchOperation{Op: "add", Path: movementPath + "/scheduledCount", Value: map[string]int{nodeName: scheduledCount}})
+	} else {
+		operations = append(operations, jsonPatchOperation{Op: "add", Path: movementPath + "/scheduledCount/" + node, Value: scheduledCount})
+	}
+	return operations
+}
+
+// updateRemainingSlots refreshes status.remainingSlots of a hint whose slots were just updated. The status
+// subresource is patched with the hint's resourceVersion as a precondition, so a concurrent slot update wins
+// and refreshes the status itself
+func (s *MultiObjectiveScheduler) updateRemainingSlots(ctx context.Context, client versioned.Interface, hint *deschedulerv1alpha1.SchedulingHint) {
+	remainingSlots := hint.Status.RemainingSlots
+	hint.UpdateRemainingSlots()
+	if hint.Status.RemainingSlots == remainingSlots {
+		return
+	}
+	patch, err := remainingSlotsPatch(hint.ResourceVersion, hint.Status.RemainingSlots)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build config from kubeconfig: %w", err)
+		s.logger.Error(err, "Failed to build remaining slots patch", "hint", hint.Name)
+		return
+	}
+	if _, err := client.DeschedulerV1alpha1().SchedulingHints().Patch(ctx, hint.Name, types.MergePatchType, patch, metav1.PatchOptions{}, "status"); err != nil {
+		s.logger.V(4).Info("Cannot update remaining slots of scheduling hint",
+			"hint", hint.Name, "remainingSlots", hint.Status.RemainingSlots, "error", err.Error())
 	}
+}
+
+// remainingSlotsPatch builds the merge patch setting status.remainingSlots, conditioned on the hint still being
+// at the given resourceVersion
+func remainingSlotsPatch(resourceVersion string, remainingSlots int) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": resourceVersion},
+		"status":   map[string]interface{}{"remainingSlots": remainingSlots},
+	})
+}
 
-	return config, nil
+// generateHintName generates hint name from fingerprint (same as descheduler)
+func (s *MultiObjectiveScheduler) generateHintName(fingerprint string) string {
+	return s.args.HintNamePrefix + fingerprint
 }
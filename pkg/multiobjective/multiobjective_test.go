@@ -0,0 +1,2684 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	clientsetfake "k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/klog/v2"
+	"k8s.io/klog/v2/textlogger"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/defaultbinder"
+	"k8s.io/kubernetes/pkg/scheduler/framework/plugins/queuesort"
+	frameworkruntime "k8s.io/kubernetes/pkg/scheduler/framework/runtime"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+	tf "k8s.io/kubernetes/pkg/scheduler/testing/framework"
+	clocktesting "k8s.io/utils/clock/testing"
+	"k8s.io/utils/ptr"
+
+	"sigs.k8s.io/scheduler-plugins/apis/config"
+	cfgv1 "sigs.k8s.io/scheduler-plugins/apis/config/v1"
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+	"sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned"
+	deschedulerfake "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/fake"
+	testutil "sigs.k8s.io/scheduler-plugins/test/util"
+)
+
+func defaultArgs() *config.MultiObjectiveArgs {
+	return &config.MultiObjectiveArgs{
+		ObjectiveWeights:              []float64{0, 0, 0},
+		StrictHint:                    cfgv1.DefaultMultiObjectiveStrictHint,
+		SystemNamespaces:              cfgv1.DefaultMultiObjectiveSystemNamespaces,
+		ControlPlaneLabels:            cfgv1.DefaultMultiObjectiveControlPlaneLabels,
+		FilterNonTargetNodes:          cfgv1.DefaultMultiObjectiveFilterNonTargetNodes,
+		FilterExhaustedTargetNodes:    cfgv1.DefaultMultiObjectiveFilterExhaustedTargetNodes,
+		SlotUpdateMaxRetries:          cfgv1.DefaultMultiObjectiveSlotUpdateMaxRetries,
+		HintNamePrefix:                cfgv1.DefaultMultiObjectiveHintNamePrefix,
+		MaxInfluence:                  cfgv1.DefaultMultiObjectiveMaxInfluence,
+		HintLookupTimeoutMilliseconds: cfgv1.DefaultMultiObjectiveHintLookupTimeoutMilliseconds,
+	}
+}
+
+func newTestFramework(ctx context.Context, t *testing.T, nodes []*v1.Node, objs ...runtime.Object) (framework.Framework, informers.SharedInformerFactory) {
+	t.Helper()
+	return newTestFrameworkWithPlugins(ctx, t, nil, nodes, objs...)
+}
+
+// newTestFrameworkWithPlugins returns a test framework that also runs the given plugins
+func newTestFrameworkWithPlugins(ctx context.Context, t *testing.T, plugins []tf.RegisterPluginFunc, nodes []*v1.Node, objs ...runtime.Object) (framework.Framework, informers.SharedInformerFactory) {
+	t.Helper()
+	// Pods among objs are also placed on their nodes in the snapshot
+	var pods []*v1.Pod
+	for _, obj := range objs {
+		if pod, ok := obj.(*v1.Pod); ok {
+			pods = append(pods, pod)
+		}
+	}
+	for _, node := range nodes {
+		objs = append(objs, node)
+	}
+	fakeclient := clientsetfake.NewSimpleClientset(objs...)
+	informerFactory := informers.NewSharedInformerFactory(fakeclient, 0)
+	registeredPlugins := append([]tf.RegisterPluginFunc{
+		tf.RegisterQueueSortPlugin(queuesort.Name, queuesort.New),
+		tf.RegisterBindPlugin(defaultbinder.Name, defaultbinder.New),
+	}, plugins...)
+	fr, err := tf.NewFramework(ctx, registeredPlugins, Name,
+		frameworkruntime.WithInformerFactory(informerFactory),
+		frameworkruntime.WithSnapshotSharedLister(testutil.NewFakeSharedLister(pods, nodes)),
+		frameworkruntime.WithKubeConfig(&restclient.Config{}),
+		frameworkruntime.WithClientSet(fakeclient),
+		frameworkruntime.WithWaitingPods(frameworkruntime.NewWaitingPodsMap()),
+		frameworkruntime.WithPodNominator(testutil.NewPodNominator(nil)))
+	if err != nil {
+		t.Fatalf("failed to create framework: %v", err)
+	}
+	return fr, informerFactory
+}
+
+func makeNodeInfo(node *v1.Node, pods ...*v1.Pod) *framework.NodeInfo {
+	nodeInfo := framework.NewNodeInfo(pods...)
+	nodeInfo.SetNode(node)
+	return nodeInfo
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    runtime.Object
+		wantErr bool
+	}{
+		{
+			name: "valid args",
+			args: defaultArgs(),
+		},
+		{
+			name:    "invalid args type",
+			args:    &config.CoschedulingArgs{},
+			wantErr: true,
+		},
+		{
+			name: "no slot update attempts",
+			args: func() runtime.Object {
+				args := defaultArgs()
+				args.SlotUpdateMaxRetries = 0
+				return args
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "max influence above max node score",
+			args: func() runtime.Object {
+				args := defaultArgs()
+				args.MaxInfluence = MaxNodeScore + 1
+				return args
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "no max influence",
+			args: func() runtime.Object {
+				args := defaultArgs()
+				args.MaxInfluence = 0
+				return args
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "negative slot confirmation timeout",
+			args: func() runtime.Object {
+				args := defaultArgs()
+				args.SlotConfirmationTimeoutSeconds = -1
+				return args
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "invalid control-plane taint effect",
+			args: func() runtime.Object {
+				args := defaultArgs()
+				args.ControlPlaneTaints = []string{"node-role.kubernetes.io/control-plane:Sometimes"}
+				return args
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "negative hint lookup timeout",
+			args: func() runtime.Object {
+				args := defaultArgs()
+				args.HintLookupTimeoutMilliseconds = -1
+				return args
+			}(),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			fr, _ := newTestFramework(ctx, t, nil)
+			p, err := New(ctx, tt.args, fr)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("New() expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("New() unexpected error: %v", err)
+			}
+
+			// The clientset is built once at construction and reused by every scheduling cycle
+			s := p.(*MultiObjectiveScheduler)
+			if s.client == nil {
+				t.Errorf("New() did not build the SchedulingHint clientset")
+			}
+		})
+	}
+}
+
+func TestSelectBestNode(t *testing.T) {
+	solution := &deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 3, "node-b": 1},
+				AvailableSlots:     map[string]int{"node-a": 3, "node-b": 1},
+			},
+		},
+	}
+
+	nodeA := st.MakeNode().Name("node-a").Label(v1.LabelHostname, "node-a").Obj()
+	nodeB := st.MakeNode().Name("node-b").Label(v1.LabelHostname, "node-b").Obj()
+	existing := st.MakePod().Namespace("default").Name("db-0").Label("app", "db").Node("node-a").Obj()
+
+	tests := []struct {
+		name            string
+		pod             *v1.Pod
+		solution        *deschedulerv1alpha1.OptimizationSolution
+		nodes           []*framework.NodeInfo
+		rsKey           string
+		expected        string
+		expectedWeights map[string]int
+	}{
+		{
+			name:            "top hint target selected",
+			pod:             st.MakePod().Namespace("default").Name("web-0").Obj(),
+			nodes:           []*framework.NodeInfo{makeNodeInfo(nodeA, existing), makeNodeInfo(nodeB)},
+			rsKey:           "default/web",
+			expected:        "node-a",
+			expectedWeights: map[string]int{"node-a": 3, "node-b": 1},
+		},
+		{
+			name: "top hint target excluded by required anti-affinity",
+			pod: st.MakePod().Namespace("default").Name("web-0").
+				PodAntiAffinityExists("app", v1.LabelHostname, st.PodAntiAffinityWithRequiredReq).Obj(),
+			nodes:           []*framework.NodeInfo{makeNodeInfo(nodeA, existing), makeNodeInfo(nodeB)},
+			rsKey:           "default/web",
+			expected:        "node-b",
+			expectedWeights: map[string]int{"node-b": 1},
+		},
+		{
+			name: "all targets excluded by required anti-affinity",
+			pod: st.MakePod().Namespace("default").Name("web-0").
+				PodAntiAffinityExists("app", v1.LabelHostname, st.PodAntiAffinityWithRequiredReq).Obj(),
+			nodes: []*framework.NodeInfo{
+				makeNodeInfo(nodeA, existing),
+				makeNodeInfo(nodeB, st.MakePod().Namespace("default").Name("db-1").Label("app", "db").Node("node-b").Obj()),
+			},
+			rsKey:    "default/web",
+			expected: "",
+		},
+		{
+			name: "preferred anti-affinity does not exclude target",
+			pod: st.MakePod().Namespace("default").Name("web-0").
+				PodAntiAffinityExists("app", v1.LabelHostname, st.PodAntiAffinityWithPreferredReq).Obj(),
+			nodes:           []*framework.NodeInfo{makeNodeInfo(nodeA, existing), makeNodeInfo(nodeB)},
+			rsKey:           "default/web",
+			expected:        "node-a",
+			expectedWeights: map[string]int{"node-a": 3, "node-b": 1},
+		},
+		{
+			name: "node with most headroom relative to target selected",
+			pod:  st.MakePod().Namespace("default").Name("web-1").Obj(),
+			solution: &deschedulerv1alpha1.OptimizationSolution{
+				Rank: 1,
+				ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+					{
+						Namespace:          "default",
+						ReplicaSetName:     "web",
+						TargetDistribution: map[string]int{"node-a": 3, "node-b": 1},
+						AvailableSlots:     map[string]int{"node-a": 2, "node-b": 1},
+						ScheduledCount:     map[string]int{"node-a": 1},
+					},
+				},
+			},
+			nodes:           []*framework.NodeInfo{makeNodeInfo(nodeA), makeNodeInfo(nodeB)},
+			rsKey:           "default/web",
+			expected:        "node-b",
+			expectedWeights: map[string]int{"node-a": 3, "node-b": 1},
+		},
+		{
+			name: "movement without slot maps",
+			pod:  st.MakePod().Namespace("default").Name("web-0").Obj(),
+			solution: &deschedulerv1alpha1.OptimizationSolution{
+				Rank:                1,
+				ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{{Namespace: "default", ReplicaSetName: "web"}},
+			},
+			nodes:    []*framework.NodeInfo{makeNodeInfo(nodeA), makeNodeInfo(nodeB)},
+			rsKey:    "default/web",
+			expected: "",
+		},
+		{
+			name:     "unknown ReplicaSet",
+			pod:      st.MakePod().Namespace("default").Name("other-0").Obj(),
+			nodes:    []*framework.NodeInfo{makeNodeInfo(nodeA), makeNodeInfo(nodeB)},
+			rsKey:    "default/other",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &MultiObjectiveScheduler{logger: klog.Background(), args: defaultArgs()}
+			sol := solution
+			if tt.solution != nil {
+				sol = tt.solution
+			}
+			got, gotWeights := s.selectBestNode(tt.pod, sol, tt.rsKey, tt.nodes, getAntiAffinityViolatingNodes(tt.pod, tt.nodes, nil))
+			if got != tt.expected {
+				t.Errorf("selectBestNode() = %q, want %q", got, tt.expected)
+			}
+			if diff := cmp.Diff(tt.expectedWeights, gotWeights, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected target weights (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSelectBestNodeTieBreak(t *testing.T) {
+	solution := &deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-c": 2, "node-a": 2, "node-b": 2, "node-d": 1},
+				AvailableSlots:     map[string]int{"node-c": 2, "node-a": 2, "node-b": 2, "node-d": 1},
+			},
+		},
+	}
+	var nodes []*framework.NodeInfo
+	for _, name := range []string{"node-d", "node-c", "node-b", "node-a"} {
+		nodes = append(nodes, makeNodeInfo(st.MakeNode().Name(name).Obj()))
+	}
+	pod := st.MakePod().Namespace("default").Name("web-0").Obj()
+	s := &MultiObjectiveScheduler{logger: klog.Background(), args: defaultArgs()}
+
+	// Map iteration order differs between runs, so repeat the selection to catch an unstable choice
+	for i := 0; i < 100; i++ {
+		if got, _ := s.selectBestNode(pod, solution, "default/web", nodes, nil); got != "node-a" {
+			t.Fatalf("selectBestNode() = %q on run %d, want %q", got, i, "node-a")
+		}
+	}
+}
+
+func TestGetAntiAffinityViolatingNodes(t *testing.T) {
+	nodeA := st.MakeNode().Name("node-a").Label(v1.LabelTopologyZone, "zone-1").Obj()
+	nodeB := st.MakeNode().Name("node-b").Label(v1.LabelTopologyZone, "zone-2").Obj()
+	nodeC := st.MakeNode().Name("node-c").Label(v1.LabelTopologyZone, "zone-1").Obj()
+
+	// avoidDB makes the pod avoid zones running pods labeled app=db in the namespaces selected by nsSelector
+	avoidDB := func(nsSelector *metav1.LabelSelector) *v1.Pod {
+		pod := st.MakePod().Namespace("default").Name("web-0").Label("app", "web").Obj()
+		pod.Spec.Affinity = &v1.Affinity{PodAntiAffinity: &v1.PodAntiAffinity{
+			RequiredDuringSchedulingIgnoredDuringExecution: []v1.PodAffinityTerm{{
+				LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "db"}},
+				NamespaceSelector: nsSelector,
+				TopologyKey:       v1.LabelTopologyZone,
+			}},
+		}}
+		return pod
+	}
+	dataDB := st.MakePod().Namespace("data").Name("db-0").Label("app", "db").Node("node-a").Obj()
+
+	tests := []struct {
+		name     string
+		pod      *v1.Pod
+		nodes    []*framework.NodeInfo
+		expected map[string]bool
+	}{
+		{
+			name:     "namespace selector matches pod in another namespace",
+			pod:      avoidDB(&metav1.LabelSelector{MatchLabels: map[string]string{"team": "data"}}),
+			nodes:    []*framework.NodeInfo{makeNodeInfo(nodeA, dataDB), makeNodeInfo(nodeB)},
+			expected: map[string]bool{"node-a": true},
+		},
+		{
+			name:  "term without namespace selector ignores pod in another namespace",
+			pod:   avoidDB(nil),
+			nodes: []*framework.NodeInfo{makeNodeInfo(nodeA, dataDB), makeNodeInfo(nodeB)},
+		},
+		{
+			name: "existing pod's term matches pod",
+			pod:  st.MakePod().Namespace("default").Name("web-0").Label("app", "web").Obj(),
+			nodes: []*framework.NodeInfo{
+				makeNodeInfo(nodeA, st.MakePod().Namespace("default").Name("web-1").Label("app", "web").Node("node-a").
+					PodAntiAffinityExists("app", v1.LabelTopologyZone, st.PodAntiAffinityWithRequiredReq).Obj()),
+				makeNodeInfo(nodeB),
+				makeNodeInfo(nodeC),
+			},
+			expected: map[string]bool{"node-a": true, "node-c": true},
+		},
+		{
+			name: "matching pod blocks every node of its topology domain",
+			pod:  avoidDB(&metav1.LabelSelector{}),
+			nodes: []*framework.NodeInfo{
+				makeNodeInfo(nodeA),
+				makeNodeInfo(nodeB),
+				makeNodeInfo(nodeC, st.MakePod().Namespace("default").Name("db-1").Label("app", "db").Node("node-c").Obj()),
+			},
+			expected: map[string]bool{"node-a": true, "node-c": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			fakeclient := clientsetfake.NewSimpleClientset(
+				&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+				&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "data", Labels: map[string]string{"team": "data"}}},
+			)
+			informerFactory := informers.NewSharedInformerFactory(fakeclient, 0)
+			nsLister := informerFactory.Core().V1().Namespaces().Lister()
+			informerFactory.Start(ctx.Done())
+			informerFactory.WaitForCacheSync(ctx.Done())
+
+			got := getAntiAffinityViolatingNodes(tt.pod, tt.nodes, nsLister)
+			if diff := cmp.Diff(tt.expected, got, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("unexpected violating nodes (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPreScoreAntiAffinityOnFilteredOutNode(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Label(v1.LabelTopologyZone, "zone-1").Obj(),
+		st.MakeNode().Name("node-b").Label(v1.LabelTopologyZone, "zone-2").Obj(),
+		st.MakeNode().Name("node-c").Label(v1.LabelTopologyZone, "zone-1").Obj(),
+	}
+	db := st.MakePod().Namespace("default").Name("db-0").Label("app", "db").Node("node-c").Obj()
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 2), db)
+	createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+				AvailableSlots:     map[string]int{"node-a": 2, "node-b": 1},
+			},
+		},
+	})
+
+	// node-c is filtered out, yet its pod still keeps the pod out of zone-1
+	pod := st.MakePod().Namespace("default").Name("web-0").
+		OwnerReference("web", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).
+		PodAntiAffinityExists("app", v1.LabelTopologyZone, st.PodAntiAffinityWithRequiredReq).Obj()
+	state := framework.NewCycleState()
+	if status := s.PreScore(ctx, state, pod, []*framework.NodeInfo{makeNodeInfo(nodes[0]), makeNodeInfo(nodes[1])}); !status.IsSuccess() {
+		t.Fatalf("PreScore() unexpected status: %v", status)
+	}
+	if got := readCycleState(state).TargetNode; got != "node-b" {
+		t.Errorf("PreScore() target node = %q, want %q", got, "node-b")
+	}
+}
+
+func TestSelectBestNodeSpreadsPods(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+		st.MakeNode().Name("node-c").Obj(),
+	}
+	nodeInfos := make([]*framework.NodeInfo, len(nodes))
+	for i, node := range nodes {
+		nodeInfos[i] = makeNodeInfo(node)
+	}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 6))
+	hint := createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 3, "node-b": 2, "node-c": 1},
+				AvailableSlots:     map[string]int{"node-a": 3, "node-b": 2, "node-c": 1},
+			},
+		},
+	})
+
+	// Successive pods go to the node with the most slots left relative to its target
+	var got []string
+	for i := 0; i < 7; i++ {
+		pod := st.MakePod().Namespace("default").Name(fmt.Sprintf("web-%d", i)).OwnerReference("web", rsOwner).Obj()
+		state := framework.NewCycleState()
+		if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+			t.Fatalf("PreScore(%s) unexpected status: %v", pod.Name, status)
+		}
+		targetNode := readCycleState(state).TargetNode
+		got = append(got, targetNode)
+		if targetNode == "" {
+			continue
+		}
+		if status := s.Reserve(ctx, state, pod, targetNode); !status.IsSuccess() {
+			t.Fatalf("Reserve(%s) unexpected status: %v", pod.Name, status)
+		}
+	}
+	want := []string{"node-a", "node-b", "node-c", "node-a", "node-b", "node-a", ""}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("unexpected target nodes (-want, +got):\n%s", diff)
+	}
+
+	updated, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get scheduling hint: %v", err)
+	}
+	wantScheduled := map[string]int{"node-a": 3, "node-b": 2, "node-c": 1}
+	if diff := cmp.Diff(wantScheduled, updated.Spec.Solutions[0].ReplicaSetMovements[0].ScheduledCount); diff != "" {
+		t.Errorf("unexpected scheduled counts (-want, +got):\n%s", diff)
+	}
+}
+
+func TestSelectTargetNode(t *testing.T) {
+	hint := &deschedulerv1alpha1.SchedulingHint{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiobjective-hints-abc"},
+		Spec: deschedulerv1alpha1.SchedulingHintSpec{
+			Solutions: []deschedulerv1alpha1.OptimizationSolution{
+				{
+					Rank: 1,
+					ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+						{
+							Namespace:          "default",
+							ReplicaSetName:     "web",
+							TargetDistribution: map[string]int{"node-a": 2},
+							AvailableSlots:     map[string]int{"node-a": 2},
+						},
+					},
+				},
+			},
+		},
+	}
+	solution := &hint.Spec.Solutions[0]
+
+	nodeA := makeNodeInfo(st.MakeNode().Name("node-a").Obj())
+	nodeB := makeNodeInfo(st.MakeNode().Name("node-b").Obj())
+
+	tests := []struct {
+		name           string
+		rsKey          string
+		nodes          []*framework.NodeInfo
+		expectedTarget string
+	}{
+		{
+			name:           "target eligible",
+			rsKey:          "default/web",
+			nodes:          []*framework.NodeInfo{nodeA, nodeB},
+			expectedTarget: "node-a",
+		},
+		{
+			name:           "no eligible target scores neutral",
+			rsKey:          "default/web",
+			nodes:          []*framework.NodeInfo{nodeB},
+			expectedTarget: "",
+		},
+		{
+			name:           "ReplicaSet not in hint scores neutral",
+			rsKey:          "default/other",
+			nodes:          []*framework.NodeInfo{nodeA, nodeB},
+			expectedTarget: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			fr, _ := newTestFramework(ctx, t, []*v1.Node{nodeA.Node(), nodeB.Node()})
+			s := &MultiObjectiveScheduler{logger: klog.Background(), handle: fr, args: defaultArgs()}
+			cycleState := &MultiObjectiveState{RSKey: tt.rsKey}
+			pod := st.MakePod().Namespace("default").Name("web-0").Obj()
+
+			s.selectTargetNode(pod, cycleState, hint, solution, tt.nodes)
+			if cycleState.TargetNode != tt.expectedTarget {
+				t.Errorf("selectTargetNode() target = %q, want %q", cycleState.TargetNode, tt.expectedTarget)
+			}
+			if cycleState.HasHint != (tt.expectedTarget != "") {
+				t.Errorf("selectTargetNode() hasHint = %v, want %v", cycleState.HasHint, tt.expectedTarget != "")
+			}
+		})
+	}
+}
+
+// testCycleState returns a cycle state holding a hint with the given number of target nodes
+func testCycleState(nodeCount int) *MultiObjectiveState {
+	movement := deschedulerv1alpha1.ReplicaSetMovement{
+		Namespace:          "default",
+		ReplicaSetName:     "web",
+		TargetDistribution: map[string]int{},
+		AvailableSlots:     map[string]int{},
+		ScheduledCount:     map[string]int{},
+	}
+	weights := map[string]int{}
+	for i := 0; i < nodeCount; i++ {
+		nodeName := fmt.Sprintf("node-%d", i)
+		movement.TargetDistribution[nodeName] = 2
+		movement.SetSlots(nodeName, 1, 1)
+		weights[nodeName] = 2
+	}
+	return &MultiObjectiveState{
+		TargetNode:    "node-0",
+		TargetWeights: weights,
+		HasHint:       true,
+		Hint: &deschedulerv1alpha1.SchedulingHint{
+			ObjectMeta: metav1.ObjectMeta{Name: "multiobjective-hints-abc"},
+			Spec: deschedulerv1alpha1.SchedulingHintSpec{
+				Solutions: []deschedulerv1alpha1.OptimizationSolution{
+					{Rank: 1, ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{movement}},
+				},
+			},
+		},
+		RSKey:        "default/web",
+		SlotConsumed: true,
+	}
+}
+
+func TestMultiObjectiveStateClone(t *testing.T) {
+	original := testCycleState(2)
+	want := testCycleState(2)
+
+	clone := original.Clone().(*MultiObjectiveState)
+	if diff := cmp.Diff(original, clone); diff != "" {
+		t.Fatalf("unexpected clone (-want, +got):\n%s", diff)
+	}
+
+	// Mutating the clone's hint and target weights leaves the original untouched
+	clone.Hint.Spec.Solutions[0].ReplicaSetMovements[0].SetSlots("node-0", 0, 2)
+	clone.Hint.Name = "multiobjective-hints-def"
+	clone.TargetWeights["node-1"] = 5
+	if diff := cmp.Diff(want, original); diff != "" {
+		t.Errorf("original changed by mutating the clone (-want, +got):\n%s", diff)
+	}
+
+	empty := &MultiObjectiveState{RSKey: "default/web"}
+	if diff := cmp.Diff(empty, empty.Clone()); diff != "" {
+		t.Errorf("unexpected clone of state without hint (-want, +got):\n%s", diff)
+	}
+}
+
+func BenchmarkMultiObjectiveStateClone(b *testing.B) {
+	for _, nodeCount := range []int{10, 100, 1000} {
+		state := testCycleState(nodeCount)
+		b.Run(fmt.Sprintf("%d nodes", nodeCount), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				_ = state.Clone()
+			}
+		})
+	}
+}
+
+func TestNormalizeScore(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxInfluence int64
+		state        *MultiObjectiveState
+		scores       framework.NodeScoreList
+		expected     framework.NodeScoreList
+	}{
+		{
+			name: "scores within range are unchanged",
+			scores: framework.NodeScoreList{
+				{Name: "node-a", Score: MinNodeScore},
+				{Name: "node-b", Score: 50},
+				{Name: "node-c", Score: MaxNodeScore},
+			},
+			expected: framework.NodeScoreList{
+				{Name: "node-a", Score: MinNodeScore},
+				{Name: "node-b", Score: 50},
+				{Name: "node-c", Score: MaxNodeScore},
+			},
+		},
+		{
+			name: "out of range scores are clamped",
+			scores: framework.NodeScoreList{
+				{Name: "node-a", Score: -20},
+				{Name: "node-b", Score: 250},
+				{Name: "node-c", Score: 99},
+			},
+			expected: framework.NodeScoreList{
+				{Name: "node-a", Score: MinNodeScore},
+				{Name: "node-b", Score: MaxNodeScore},
+				{Name: "node-c", Score: 99},
+			},
+		},
+		{
+			name: "hint spreads scores proportionally to target distribution",
+			state: &MultiObjectiveState{
+				TargetNode:    "node-a",
+				TargetWeights: map[string]int{"node-a": 4, "node-b": 2, "node-c": 1},
+				HasHint:       true,
+			},
+			scores: framework.NodeScoreList{
+				{Name: "node-a", Score: MaxNodeScore},
+				{Name: "node-b", Score: MinNodeScore},
+				{Name: "node-c", Score: MinNodeScore},
+				{Name: "node-d", Score: MinNodeScore},
+			},
+			expected: framework.NodeScoreList{
+				{Name: "node-a", Score: MaxNodeScore},
+				{Name: "node-b", Score: 50},
+				{Name: "node-c", Score: 25},
+				{Name: "node-d", Score: MinNodeScore},
+			},
+		},
+		{
+			name: "selected target node outscores target nodes with larger target counts",
+			state: &MultiObjectiveState{
+				TargetNode:    "node-b",
+				TargetWeights: map[string]int{"node-a": 4, "node-b": 2, "node-c": 1},
+				HasHint:       true,
+			},
+			scores: framework.NodeScoreList{
+				{Name: "node-a", Score: MinNodeScore},
+				{Name: "node-b", Score: MaxNodeScore},
+				{Name: "node-c", Score: MinNodeScore},
+			},
+			expected: framework.NodeScoreList{
+				{Name: "node-a", Score: MaxNodeScore - 1},
+				{Name: "node-b", Score: MaxNodeScore},
+				{Name: "node-c", Score: 25},
+			},
+		},
+		{
+			name:         "max influence scales spread scores",
+			maxInfluence: 40,
+			state: &MultiObjectiveState{
+				TargetNode:    "node-a",
+				TargetWeights: map[string]int{"node-a": 4, "node-b": 2, "node-c": 1},
+				HasHint:       true,
+			},
+			scores: framework.NodeScoreList{
+				{Name: "node-a", Score: 40},
+				{Name: "node-b", Score: MinNodeScore},
+				{Name: "node-c", Score: MinNodeScore},
+				{Name: "node-d", Score: MinNodeScore},
+			},
+			expected: framework.NodeScoreList{
+				{Name: "node-a", Score: 40},
+				{Name: "node-b", Score: 20},
+				{Name: "node-c", Score: 10},
+				{Name: "node-d", Score: MinNodeScore},
+			},
+		},
+		{
+			name:         "scores above max influence are clamped",
+			maxInfluence: 40,
+			scores: framework.NodeScoreList{
+				{Name: "node-a", Score: MaxNodeScore},
+				{Name: "node-b", Score: 30},
+			},
+			expected: framework.NodeScoreList{
+				{Name: "node-a", Score: 40},
+				{Name: "node-b", Score: 30},
+			},
+		},
+		{
+			name: "no hint keeps all-or-nothing scores",
+			state: &MultiObjectiveState{
+				TargetWeights: map[string]int{"node-a": 4, "node-b": 2},
+				HasHint:       false,
+			},
+			scores: framework.NodeScoreList{
+				{Name: "node-a", Score: MinNodeScore},
+				{Name: "node-b", Score: MinNodeScore},
+			},
+			expected: framework.NodeScoreList{
+				{Name: "node-a", Score: MinNodeScore},
+				{Name: "node-b", Score: MinNodeScore},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := defaultArgs()
+			if tt.maxInfluence != 0 {
+				args.MaxInfluence = tt.maxInfluence
+			}
+			s := &MultiObjectiveScheduler{logger: klog.Background(), args: args}
+			state := framework.NewCycleState()
+			if tt.state != nil {
+				state.Write(stateKey, tt.state)
+			}
+			status := s.ScoreExtensions().NormalizeScore(context.Background(), state, st.MakePod().Obj(), tt.scores)
+			if !status.IsSuccess() {
+				t.Fatalf("NormalizeScore() returned %v", status)
+			}
+			if diff := cmp.Diff(tt.expected, tt.scores); diff != "" {
+				t.Errorf("unexpected normalized scores (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func makeReplicaSet(namespace, name string, replicas int32) *appsv1.ReplicaSet {
+	return &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       appsv1.ReplicaSetSpec{Replicas: ptr.To(replicas)},
+	}
+}
+
+func TestGetClusterFingerprint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-b").Obj(),
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("control-plane").Label("node-role.kubernetes.io/control-plane", "").Obj(),
+	}
+	replicaSets := []runtime.Object{
+		makeReplicaSet("default", "web", 3),
+		makeReplicaSet("default", "scaled-down", 0),
+		makeReplicaSet("kube-system", "coredns", 2),
+		makeReplicaSet("shop", "cart", 1),
+	}
+
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, replicaSets...)
+	fr := s.handle
+
+	got, err := s.getClusterFingerprint()
+	if err != nil {
+		t.Fatalf("getClusterFingerprint() unexpected error: %v", err)
+	}
+
+	// Fingerprint computed from live List calls for the same state
+	nodeList, err := fr.ClientSet().CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list nodes: %v", err)
+	}
+	rsList, err := fr.ClientSet().AppsV1().ReplicaSets("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("failed to list ReplicaSets: %v", err)
+	}
+	listedNodes := make([]*v1.Node, len(nodeList.Items))
+	for i := range nodeList.Items {
+		listedNodes[i] = &nodeList.Items[i]
+	}
+	listedReplicaSets := make([]*appsv1.ReplicaSet, len(rsList.Items))
+	for i := range rsList.Items {
+		listedReplicaSets[i] = &rsList.Items[i]
+	}
+	if want := s.calculateClusterFingerprintFromReplicaSets(listedNodes, listedReplicaSets); got != want {
+		t.Errorf("getClusterFingerprint() = %q, want %q from List-based path", got, want)
+	}
+
+	// The fingerprint formula must keep matching the descheduler's
+	hash := sha256.Sum256([]byte("nodes:node-a,node-b|replicasets:default/web=3;shop/cart=1"))
+	if want := fmt.Sprintf("%x", hash)[:16]; got != want {
+		t.Errorf("getClusterFingerprint() = %q, want %q", got, want)
+	}
+}
+
+func TestCustomSystemNamespacesAndControlPlaneLabels(t *testing.T) {
+	args := defaultArgs()
+	args.SystemNamespaces = []string{"monitoring"}
+	args.ControlPlaneLabels = []string{"node-role.kubernetes.io/master"}
+	s := &MultiObjectiveScheduler{logger: klog.Background(), args: args}
+
+	masterNode := st.MakeNode().Name("master").Label("node-role.kubernetes.io/master", "").Obj()
+	workerNode := st.MakeNode().Name("worker").Label("node-role.kubernetes.io/control-plane", "").Obj()
+
+	t.Run("fingerprint", func(t *testing.T) {
+		got := s.calculateClusterFingerprintFromReplicaSets(
+			[]*v1.Node{masterNode, workerNode},
+			[]*appsv1.ReplicaSet{
+				makeReplicaSet("monitoring", "prometheus", 1),
+				makeReplicaSet("kube-system", "coredns", 2),
+			})
+		hash := sha256.Sum256([]byte("nodes:worker|replicasets:kube-system/coredns=2"))
+		if want := fmt.Sprintf("%x", hash)[:16]; got != want {
+			t.Errorf("calculateClusterFingerprintFromReplicaSets() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("selectBestNode", func(t *testing.T) {
+		solution := &deschedulerv1alpha1.OptimizationSolution{
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					Namespace:          "default",
+					ReplicaSetName:     "web",
+					TargetDistribution: map[string]int{"master": 5, "worker": 1},
+					AvailableSlots:     map[string]int{"master": 5, "worker": 1},
+				},
+			},
+		}
+		pod := st.MakePod().Namespace("default").Name("web-0").Obj()
+		nodes := []*framework.NodeInfo{makeNodeInfo(masterNode), makeNodeInfo(workerNode)}
+		if got, _ := s.selectBestNode(pod, solution, "default/web", nodes, nil); got != "worker" {
+			t.Errorf("selectBestNode() = %q, want %q", got, "worker")
+		}
+	})
+
+	t.Run("isPodEligible", func(t *testing.T) {
+		rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+		monitoringPod := st.MakePod().Namespace("monitoring").Name("prometheus-0").
+			OwnerReference("prometheus", rsOwner).Phase(v1.PodRunning).Obj()
+		if s.isPodEligible(monitoringPod) {
+			t.Errorf("isPodEligible() = true for pod in custom system namespace")
+		}
+		kubeSystemPod := st.MakePod().Namespace("kube-system").Name("coredns-0").
+			OwnerReference("coredns", rsOwner).Phase(v1.PodRunning).Obj()
+		if !s.isPodEligible(kubeSystemPod) {
+			t.Errorf("isPodEligible() = false for pod outside custom system namespaces")
+		}
+	})
+}
+
+func TestControlPlaneTaints(t *testing.T) {
+	args := defaultArgs()
+	args.ControlPlaneTaints = []string{"node-role.kubernetes.io/control-plane:NoSchedule", "node-role.kubernetes.io/master"}
+	controlPlaneTaints, err := parseControlPlaneTaints(args.ControlPlaneTaints)
+	if err != nil {
+		t.Fatalf("parseControlPlaneTaints() unexpected error: %v", err)
+	}
+	s := &MultiObjectiveScheduler{logger: klog.Background(), args: args, controlPlaneTaints: controlPlaneTaints}
+
+	tainted := func(name, key string, effect v1.TaintEffect) *v1.Node {
+		node := st.MakeNode().Name(name).Obj()
+		node.Spec.Taints = []v1.Taint{{Key: key, Effect: effect}}
+		return node
+	}
+	controlPlane := tainted("control-plane", "node-role.kubernetes.io/control-plane", v1.TaintEffectNoSchedule)
+	legacyMaster := tainted("master", "node-role.kubernetes.io/master", v1.TaintEffectNoExecute)
+	otherEffect := tainted("preferred", "node-role.kubernetes.io/control-plane", v1.TaintEffectPreferNoSchedule)
+	worker := tainted("worker", "dedicated", v1.TaintEffectNoSchedule)
+
+	for _, tc := range []struct {
+		node *v1.Node
+		want bool
+	}{
+		{node: controlPlane, want: true},
+		{node: legacyMaster, want: true},
+		{node: otherEffect, want: false},
+		{node: worker, want: false},
+	} {
+		if got := s.isControlPlaneNode(tc.node); got != tc.want {
+			t.Errorf("isControlPlaneNode(%s) = %v, want %v", tc.node.Name, got, tc.want)
+		}
+	}
+
+	t.Run("fingerprint", func(t *testing.T) {
+		got := s.calculateClusterFingerprintFromReplicaSets(
+			[]*v1.Node{controlPlane, legacyMaster, otherEffect, worker},
+			[]*appsv1.ReplicaSet{makeReplicaSet("default", "web", 2)})
+		hash := sha256.Sum256([]byte("nodes:preferred,worker|replicasets:default/web=2"))
+		if want := fmt.Sprintf("%x", hash)[:16]; got != want {
+			t.Errorf("calculateClusterFingerprintFromReplicaSets() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("selectBestNode", func(t *testing.T) {
+		solution := &deschedulerv1alpha1.OptimizationSolution{
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					Namespace:          "default",
+					ReplicaSetName:     "web",
+					TargetDistribution: map[string]int{"control-plane": 5, "worker": 1},
+					AvailableSlots:     map[string]int{"control-plane": 5, "worker": 1},
+				},
+			},
+		}
+		pod := st.MakePod().Namespace("default").Name("web-0").Obj()
+		nodes := []*framework.NodeInfo{makeNodeInfo(controlPlane), makeNodeInfo(worker)}
+		if got, _ := s.selectBestNode(pod, solution, "default/web", nodes, nil); got != "worker" {
+			t.Errorf("selectBestNode() = %q, want %q", got, "worker")
+		}
+	})
+}
+
+// newTestScheduler builds the plugin on a test framework whose SchedulingHint client is a fake clientset
+func newTestScheduler(ctx context.Context, t *testing.T, args *config.MultiObjectiveArgs, nodes []*v1.Node, objs ...runtime.Object) *MultiObjectiveScheduler {
+	t.Helper()
+	return newTestSchedulerWithClient(ctx, t, args, deschedulerfake.NewSimpleClientset(), nodes, objs...)
+}
+
+// newTestSchedulerWithClient builds the plugin on a test framework with the given SchedulingHint client
+func newTestSchedulerWithClient(ctx context.Context, t *testing.T, args *config.MultiObjectiveArgs, client versioned.Interface, nodes []*v1.Node, objs ...runtime.Object) *MultiObjectiveScheduler {
+	t.Helper()
+	fr, informerFactory := newTestFramework(ctx, t, nodes, objs...)
+	p, err := newWithClient(ctx, args, fr, client)
+	if err != nil {
+		t.Fatalf("newWithClient() unexpected error: %v", err)
+	}
+	informerFactory.Start(ctx.Done())
+	informerFactory.WaitForCacheSync(ctx.Done())
+	return p.(*MultiObjectiveScheduler)
+}
+
+// createHint stores a scheduling hint for the scheduler's current cluster fingerprint
+func createHint(ctx context.Context, t *testing.T, s *MultiObjectiveScheduler, solutions ...deschedulerv1alpha1.OptimizationSolution) *deschedulerv1alpha1.SchedulingHint {
+	t.Helper()
+	fingerprint, err := s.getClusterFingerprint()
+	if err != nil {
+		t.Fatalf("getClusterFingerprint() unexpected error: %v", err)
+	}
+	hint := &deschedulerv1alpha1.SchedulingHint{
+		ObjectMeta: metav1.ObjectMeta{Name: s.generateHintName(fingerprint)},
+		Spec: deschedulerv1alpha1.SchedulingHintSpec{
+			ClusterFingerprint: fingerprint,
+			Solutions:          solutions,
+		},
+	}
+	hint, err = s.client.DeschedulerV1alpha1().SchedulingHints().Create(ctx, hint, metav1.CreateOptions{})
+	if err != nil {
+		t.Fatalf("failed to create scheduling hint: %v", err)
+	}
+	return hint
+}
+
+func TestFilter(t *testing.T) {
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+		st.MakeNode().Name("node-c").Obj(),
+	}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+	movement := func(rsName string) deschedulerv1alpha1.OptimizationSolution {
+		return deschedulerv1alpha1.OptimizationSolution{
+			Rank: 1,
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					Namespace:          "default",
+					ReplicaSetName:     rsName,
+					TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+					AvailableSlots:     map[string]int{"node-a": 2, "node-b": 0},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name                 string
+		filterNonTargetNodes bool
+		solutions            []deschedulerv1alpha1.OptimizationSolution
+		wantSchedulable      map[string]bool
+	}{
+		{
+			name:                 "filter disabled",
+			filterNonTargetNodes: false,
+			solutions:            []deschedulerv1alpha1.OptimizationSolution{movement("web")},
+			wantSchedulable:      map[string]bool{"node-a": true, "node-b": true, "node-c": true},
+		},
+		{
+			name:                 "hint present keeps only nodes with available slots",
+			filterNonTargetNodes: true,
+			solutions:            []deschedulerv1alpha1.OptimizationSolution{movement("web")},
+			wantSchedulable:      map[string]bool{"node-a": true, "node-b": false, "node-c": false},
+		},
+		{
+			name:                 "hint absent",
+			filterNonTargetNodes: true,
+			wantSchedulable:      map[string]bool{"node-a": true, "node-b": true, "node-c": true},
+		},
+		{
+			name:                 "hint without movement for the pod's ReplicaSet",
+			filterNonTargetNodes: true,
+			solutions:            []deschedulerv1alpha1.OptimizationSolution{movement("api")},
+			wantSchedulable:      map[string]bool{"node-a": true, "node-b": true, "node-c": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			args := defaultArgs()
+			args.FilterNonTargetNodes = tt.filterNonTargetNodes
+			s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 3))
+			if tt.solutions != nil {
+				createHint(ctx, t, s, tt.solutions...)
+			}
+			fakeClient := s.client.(*deschedulerfake.Clientset)
+			fakeClient.ClearActions()
+
+			state := framework.NewCycleState()
+			for _, node := range nodes {
+				status := s.Filter(ctx, state, pod, makeNodeInfo(node))
+				if got := status.IsSuccess(); got != tt.wantSchedulable[node.Name] {
+					t.Errorf("Filter(%s) schedulable = %v, want %v (status %v)", node.Name, got, tt.wantSchedulable[node.Name], status)
+				}
+				if !status.IsSuccess() && status.Code() != framework.Unschedulable {
+					t.Errorf("Filter(%s) code = %v, want %v", node.Name, status.Code(), framework.Unschedulable)
+				}
+			}
+
+			// The hint is looked up at most once per scheduling cycle
+			wantLookups := 1
+			if !tt.filterNonTargetNodes {
+				wantLookups = 0
+			}
+			if got := len(fakeClient.Actions()); got != wantLookups {
+				t.Errorf("Filter() made %d SchedulingHint requests, want %d", got, wantLookups)
+			}
+		})
+	}
+}
+
+func TestFilterExhaustedTargetNodes(t *testing.T) {
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+		st.MakeNode().Name("node-c").Obj(),
+	}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+	solution := func(slotsB int) deschedulerv1alpha1.OptimizationSolution {
+		return deschedulerv1alpha1.OptimizationSolution{
+			Rank: 1,
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					Namespace:          "default",
+					ReplicaSetName:     "web",
+					TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+					AvailableSlots:     map[string]int{"node-a": 0, "node-b": slotsB},
+					ScheduledCount:     map[string]int{"node-a": 2, "node-b": 1 - slotsB},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		disabled        bool
+		dryRun          bool
+		clusterNodes    []string
+		slotsB          int
+		wantSchedulable map[string]bool
+	}{
+		{
+			name:            "exhausted target node filtered while another has slots",
+			slotsB:          1,
+			wantSchedulable: map[string]bool{"node-a": false, "node-b": true, "node-c": true},
+		},
+		{
+			name:            "all target nodes exhausted",
+			slotsB:          0,
+			wantSchedulable: map[string]bool{"node-a": true, "node-b": true, "node-c": true},
+		},
+		{
+			name:            "filter disabled",
+			disabled:        true,
+			slotsB:          1,
+			wantSchedulable: map[string]bool{"node-a": true, "node-b": true, "node-c": true},
+		},
+		{
+			name:            "dry run",
+			dryRun:          true,
+			slotsB:          1,
+			wantSchedulable: map[string]bool{"node-a": true, "node-b": true, "node-c": true},
+		},
+		{
+			name:            "stale hint",
+			clusterNodes:    []string{"node-a", "node-b", "node-c", "node-d"},
+			slotsB:          1,
+			wantSchedulable: map[string]bool{"node-a": true, "node-b": true, "node-c": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			args := defaultArgs()
+			args.FilterExhaustedTargetNodes = !tt.disabled
+			args.DryRun = tt.dryRun
+			s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 3))
+			hint := createHint(ctx, t, s, solution(tt.slotsB))
+			if tt.clusterNodes != nil {
+				hint.Spec.ClusterNodes = tt.clusterNodes
+				if _, err := s.client.DeschedulerV1alpha1().SchedulingHints().Update(ctx, hint, metav1.UpdateOptions{}); err != nil {
+					t.Fatalf("failed to update scheduling hint: %v", err)
+				}
+			}
+
+			state := framework.NewCycleState()
+			for _, node := range nodes {
+				status := s.Filter(ctx, state, pod, makeNodeInfo(node))
+				if got := status.IsSuccess(); got != tt.wantSchedulable[node.Name] {
+					t.Errorf("Filter(%s) schedulable = %v, want %v (status %v)", node.Name, got, tt.wantSchedulable[node.Name], status)
+				}
+				if !status.IsSuccess() && status.Code() != framework.Unschedulable {
+					t.Errorf("Filter(%s) code = %v, want %v", node.Name, status.Code(), framework.Unschedulable)
+				}
+			}
+		})
+	}
+}
+
+func TestFilterStrictHint(t *testing.T) {
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+	}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	solution := func(slotsA int) deschedulerv1alpha1.OptimizationSolution {
+		return deschedulerv1alpha1.OptimizationSolution{
+			Rank: 1,
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					Namespace:          "default",
+					ReplicaSetName:     "web",
+					TargetDistribution: map[string]int{"node-a": 2},
+					AvailableSlots:     map[string]int{"node-a": slotsA},
+					ScheduledCount:     map[string]int{"node-a": 2 - slotsA},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		strictHint      bool
+		dryRun          bool
+		rsName          string
+		nodes           []*v1.Node
+		slotsA          int
+		wantSchedulable map[string]bool
+	}{
+		{
+			name:            "target with slots left",
+			strictHint:      true,
+			rsName:          "web",
+			nodes:           nodes,
+			slotsA:          1,
+			wantSchedulable: map[string]bool{"node-a": true, "node-b": false},
+		},
+		{
+			name:            "no target with slots left",
+			strictHint:      true,
+			rsName:          "web",
+			nodes:           nodes,
+			slotsA:          0,
+			wantSchedulable: map[string]bool{"node-a": false, "node-b": false},
+		},
+		{
+			// Another plugin filtered out the target node, which still has slots left
+			name:            "target node infeasible",
+			strictHint:      true,
+			rsName:          "web",
+			nodes:           nodes[1:],
+			slotsA:          1,
+			wantSchedulable: map[string]bool{"node-b": false},
+		},
+		{
+			name:            "lenient mode",
+			rsName:          "web",
+			nodes:           nodes,
+			slotsA:          0,
+			wantSchedulable: map[string]bool{"node-a": true, "node-b": true},
+		},
+		{
+			name:            "ReplicaSet not in hint",
+			strictHint:      true,
+			rsName:          "other",
+			nodes:           nodes,
+			slotsA:          0,
+			wantSchedulable: map[string]bool{"node-a": true, "node-b": true},
+		},
+		{
+			name:            "dry run",
+			strictHint:      true,
+			dryRun:          true,
+			rsName:          "web",
+			nodes:           nodes,
+			slotsA:          0,
+			wantSchedulable: map[string]bool{"node-a": true, "node-b": true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			args := defaultArgs()
+			args.StrictHint = tt.strictHint
+			args.DryRun = tt.dryRun
+			s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", tt.rsName, 2))
+			createHint(ctx, t, s, solution(tt.slotsA))
+			pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference(tt.rsName, rsOwner).Obj()
+
+			state := framework.NewCycleState()
+			for _, node := range tt.nodes {
+				status := s.Filter(ctx, state, pod, makeNodeInfo(node))
+				if got := status.IsSuccess(); got != tt.wantSchedulable[node.Name] {
+					t.Errorf("Filter(%s) schedulable = %v, want %v (status %v)", node.Name, got, tt.wantSchedulable[node.Name], status)
+				}
+				if !status.IsSuccess() && status.Code() != framework.Unschedulable {
+					t.Errorf("Filter(%s) code = %v, want %v", node.Name, status.Code(), framework.Unschedulable)
+				}
+			}
+		})
+	}
+}
+
+func TestPreFilterLooksUpHintOncePerCycle(t *testing.T) {
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+		st.MakeNode().Name("node-c").Obj(),
+	}
+	nodeInfos := make([]*framework.NodeInfo, len(nodes))
+	for i, node := range nodes {
+		nodeInfos[i] = makeNodeInfo(node)
+	}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+	solution := deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+				AvailableSlots:     map[string]int{"node-a": 2, "node-b": 0},
+			},
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		filterNonTargetNodes bool
+		withHint             bool
+		wantSkip             bool
+		wantScores           map[string]int64
+	}{
+		{
+			name:                 "hint used by Filter and Score",
+			filterNonTargetNodes: true,
+			withHint:             true,
+			wantScores:           map[string]int64{"node-a": MaxNodeScore, "node-b": MinNodeScore, "node-c": MinNodeScore},
+		},
+		{
+			name:                 "hint used by Score only",
+			filterNonTargetNodes: false,
+			withHint:             true,
+			wantSkip:             true,
+			wantScores:           map[string]int64{"node-a": MaxNodeScore, "node-b": MinNodeScore, "node-c": MinNodeScore},
+		},
+		{
+			name:                 "no hint",
+			filterNonTargetNodes: true,
+			wantSkip:             true,
+			wantScores:           map[string]int64{"node-a": MinNodeScore, "node-b": MinNodeScore, "node-c": MinNodeScore},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			args := defaultArgs()
+			args.FilterNonTargetNodes = tt.filterNonTargetNodes
+			s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 3))
+			if tt.withHint {
+				createHint(ctx, t, s, solution)
+			}
+			// Slots are reconciled before the first scheduling cycle only
+			s.activate(ctx)
+			fakeClient := s.client.(*deschedulerfake.Clientset)
+			fakeClient.ClearActions()
+
+			state := framework.NewCycleState()
+			_, status := s.PreFilter(ctx, state, pod)
+			if got := status.IsSkip(); got != tt.wantSkip {
+				t.Fatalf("PreFilter() skip = %v, want %v (status %v)", got, tt.wantSkip, status)
+			}
+			if !tt.wantSkip {
+				for _, nodeInfo := range nodeInfos {
+					s.Filter(ctx, state, pod, nodeInfo)
+				}
+			}
+			if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+				t.Fatalf("PreScore() unexpected status: %v", status)
+			}
+			for _, node := range nodes {
+				score, status := s.Score(ctx, state, pod, node.Name)
+				if !status.IsSuccess() {
+					t.Fatalf("Score(%s) unexpected status: %v", node.Name, status)
+				}
+				if score != tt.wantScores[node.Name] {
+					t.Errorf("Score(%s) = %d, want %d", node.Name, score, tt.wantScores[node.Name])
+				}
+			}
+
+			if got := len(fakeClient.Actions()); got != 1 {
+				t.Errorf("scheduling cycle made %d SchedulingHint requests, want 1", got)
+			}
+		})
+	}
+}
+
+func TestMaxInfluence(t *testing.T) {
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+	}
+	nodeInfos := []*framework.NodeInfo{makeNodeInfo(nodes[0]), makeNodeInfo(nodes[1])}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+
+	for _, maxInfluence := range []int64{100, 50, 10} {
+		t.Run(fmt.Sprintf("max influence %d", maxInfluence), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			args := defaultArgs()
+			args.MaxInfluence = maxInfluence
+			s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 2))
+			createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+				Rank: 1,
+				ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+					{
+						Namespace:          "default",
+						ReplicaSetName:     "web",
+						TargetDistribution: map[string]int{"node-a": 2},
+						AvailableSlots:     map[string]int{"node-a": 2},
+					},
+				},
+			})
+
+			state := framework.NewCycleState()
+			if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+				t.Fatalf("PreScore() unexpected status: %v", status)
+			}
+			scores := make(framework.NodeScoreList, 0, len(nodes))
+			for _, node := range nodes {
+				score, status := s.Score(ctx, state, pod, node.Name)
+				if !status.IsSuccess() {
+					t.Fatalf("Score(%s) unexpected status: %v", node.Name, status)
+				}
+				scores = append(scores, framework.NodeScore{Name: node.Name, Score: score})
+			}
+			if status := s.NormalizeScore(ctx, state, pod, scores); !status.IsSuccess() {
+				t.Fatalf("NormalizeScore() unexpected status: %v", status)
+			}
+
+			want := framework.NodeScoreList{
+				{Name: "node-a", Score: maxInfluence},
+				{Name: "node-b", Score: MinNodeScore},
+			}
+			if diff := cmp.Diff(want, scores); diff != "" {
+				t.Errorf("unexpected scores (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestReserveUnreserve(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+		st.MakeNode().Name("node-c").Obj(),
+	}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 3))
+	hint := createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+				AvailableSlots:     map[string]int{"node-a": 2, "node-b": 1},
+			},
+		},
+	})
+
+	getSlots := func() (int, int) {
+		t.Helper()
+		got, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get scheduling hint: %v", err)
+		}
+		movement := got.Spec.Solutions[0].ReplicaSetMovements[0]
+		return movement.AvailableSlots["node-a"], movement.ScheduledCount["node-a"]
+	}
+
+	state := framework.NewCycleState()
+	nodeInfos := []*framework.NodeInfo{makeNodeInfo(nodes[0]), makeNodeInfo(nodes[1]), makeNodeInfo(nodes[2])}
+	if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+		t.Fatalf("PreScore() unexpected status: %v", status)
+	}
+
+	// Scoring every candidate node only reads the hint
+	for _, node := range nodes {
+		if _, status := s.Score(ctx, state, pod, node.Name); !status.IsSuccess() {
+			t.Fatalf("Score(%s) unexpected status: %v", node.Name, status)
+		}
+	}
+	if available, scheduled := getSlots(); available != 2 || scheduled != 0 {
+		t.Errorf("after Score: slots = (%d available, %d scheduled), want (2, 0)", available, scheduled)
+	}
+
+	// Reserving on a non-target node consumes nothing
+	if status := s.Reserve(ctx, state, pod, "node-c"); !status.IsSuccess() {
+		t.Fatalf("Reserve(node-c) unexpected status: %v", status)
+	}
+	s.Unreserve(ctx, state, pod, "node-c")
+	if available, scheduled := getSlots(); available != 2 || scheduled != 0 {
+		t.Errorf("after non-target Reserve: slots = (%d available, %d scheduled), want (2, 0)", available, scheduled)
+	}
+
+	// Reserve consumes exactly one slot on the target node
+	if status := s.Reserve(ctx, state, pod, "node-a"); !status.IsSuccess() {
+		t.Fatalf("Reserve(node-a) unexpected status: %v", status)
+	}
+	if available, scheduled := getSlots(); available != 1 || scheduled != 1 {
+		t.Errorf("after Reserve: slots = (%d available, %d scheduled), want (1, 1)", available, scheduled)
+	}
+
+	// A failed binding returns the slot, and only once
+	s.Unreserve(ctx, state, pod, "node-a")
+	s.Unreserve(ctx, state, pod, "node-a")
+	if available, scheduled := getSlots(); available != 2 || scheduled != 0 {
+		t.Errorf("after Unreserve: slots = (%d available, %d scheduled), want (2, 0)", available, scheduled)
+	}
+}
+
+func TestReserveWithoutPreScore(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj(), st.MakeNode().Name("node-b").Obj()}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+	args := defaultArgs()
+	args.FilterNonTargetNodes = true
+	s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 2), pod)
+	hint := createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 1},
+				AvailableSlots:     map[string]int{"node-a": 1},
+			},
+		},
+	})
+	getSlots := func() (int, int) {
+		t.Helper()
+		got, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get scheduling hint: %v", err)
+		}
+		movement := got.Spec.Solutions[0].ReplicaSetMovements[0]
+		return movement.AvailableSlots["node-a"], movement.ScheduledCount["node-a"]
+	}
+
+	// The hint filter leaves a single feasible node, so the scheduler skips PreScore and Score
+	state := framework.NewCycleState()
+	if _, status := s.PreFilter(ctx, state, pod); !status.IsSuccess() {
+		t.Fatalf("PreFilter() unexpected status: %v", status)
+	}
+	var feasible []string
+	for _, node := range nodes {
+		if status := s.Filter(ctx, state, pod, makeNodeInfo(node)); status.IsSuccess() {
+			feasible = append(feasible, node.Name)
+		}
+	}
+	if diff := cmp.Diff([]string{"node-a"}, feasible); diff != "" {
+		t.Fatalf("unexpected feasible nodes (-want, +got):\n%s", diff)
+	}
+	if status := s.Reserve(ctx, state, pod, "node-a"); !status.IsSuccess() {
+		t.Fatalf("Reserve() unexpected status: %v", status)
+	}
+	if available, scheduled := getSlots(); available != 0 || scheduled != 1 {
+		t.Errorf("after Reserve: slots = (%d available, %d scheduled), want (0, 1)", available, scheduled)
+	}
+	if status := s.PreBind(ctx, state, pod, "node-a"); !status.IsSuccess() {
+		t.Fatalf("PreBind() unexpected status: %v", status)
+	}
+	got, err := s.handle.ClientSet().CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get pod: %v", err)
+	}
+	if want := (consumedSlot{hintName: hint.Name, solutionIndex: 0, nodeName: "node-a"}).String(); got.Annotations[ConsumedSlotAnnotation] != want {
+		t.Errorf("pod annotation %s = %q, want %q", ConsumedSlotAnnotation, got.Annotations[ConsumedSlotAnnotation], want)
+	}
+
+	// Another pod of the cycle's hint finds the slot taken: it is rejected and Unreserve releases nothing
+	other := st.MakePod().Namespace("default").Name("web-1").OwnerReference("web", rsOwner).Obj()
+	otherState := framework.NewCycleState()
+	otherState.Write(hintStateKey, &hintState{
+		hint:          hint,
+		solutionIndex: 0,
+		movement:      &hint.Spec.Solutions[0].ReplicaSetMovements[0],
+	})
+	if status := s.Reserve(ctx, otherState, other, "node-a"); status.Code() != framework.Unschedulable {
+		t.Fatalf("Reserve() status = %v, want Unschedulable", status)
+	}
+	s.Unreserve(ctx, otherState, other, "node-a")
+	if available, scheduled := getSlots(); available != 0 || scheduled != 1 {
+		t.Errorf("after failed Reserve: slots = (%d available, %d scheduled), want (0, 1)", available, scheduled)
+	}
+}
+
+func TestSlotUpdatesWithoutSlotMaps(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj()}
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 1))
+	hint := createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{Namespace: "default", ReplicaSetName: "web"},
+		},
+	})
+
+	// A partially populated movement has neither slots to consume nor consumed slots to release
+	if _, consumed := s.tryConsumeSlot(ctx, hint, 0, "default/web", "node-a"); consumed {
+		t.Errorf("tryConsumeSlot() consumed a slot of a movement without slots")
+	}
+	if released := s.tryReleaseSlot(ctx, hint, 0, "default/web", "node-a"); released {
+		t.Errorf("tryReleaseSlot() released a slot of a movement without scheduled pods")
+	}
+
+	nodeInfo := makeNodeInfo(nodes[0])
+	if got := s.getAvailableSlotsForReplicaSet(&hint.Spec.Solutions[0], "default/web", "node-a"); got != 0 {
+		t.Errorf("getAvailableSlotsForReplicaSet() = %d, want 0", got)
+	}
+	if got, _ := s.selectBestNode(st.MakePod().Namespace("default").Name("web-0").Obj(), &hint.Spec.Solutions[0], "default/web", []*framework.NodeInfo{nodeInfo}, nil); got != "" {
+		t.Errorf("selectBestNode() = %q, want no target node", got)
+	}
+}
+
+func TestRemainingSlotsStatus(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj(), st.MakeNode().Name("node-b").Obj()}
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 3))
+	hint := createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+				AvailableSlots:     map[string]int{"node-a": 2, "node-b": 1},
+			},
+		},
+	})
+	remainingSlots := func() int {
+		t.Helper()
+		got, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get scheduling hint: %v", err)
+		}
+		return got.Status.RemainingSlots
+	}
+
+	if _, consumed := s.tryConsumeSlot(ctx, hint, 0, "default/web", "node-a"); !consumed {
+		t.Fatalf("tryConsumeSlot() did not consume a slot")
+	}
+	if got := remainingSlots(); got != 2 {
+		t.Errorf("remainingSlots after consume = %d, want 2", got)
+	}
+	if released := s.tryReleaseSlot(ctx, hint, 0, "default/web", "node-a"); !released {
+		t.Fatalf("tryReleaseSlot() did not release the slot")
+	}
+	if got := remainingSlots(); got != 3 {
+		t.Errorf("remainingSlots after release = %d, want 3", got)
+	}
+}
+
+func TestTryConsumeSlotConflict(t *testing.T) {
+	tests := []struct {
+		name           string
+		conflicts      int
+		maxRetries     int64
+		wantConsumed   bool
+		wantAvailable  int
+		wantScheduled  int
+		wantMinBackoff time.Duration
+		wantMaxBackoff time.Duration
+	}{
+		{
+			name:           "retries after a concurrent update",
+			conflicts:      1,
+			maxRetries:     3,
+			wantConsumed:   true,
+			wantAvailable:  1,
+			wantScheduled:  2,
+			wantMinBackoff: 10 * time.Millisecond,
+			wantMaxBackoff: 15 * time.Millisecond,
+		},
+		{
+			name:           "gives up after repeated conflicts",
+			conflicts:      3,
+			maxRetries:     3,
+			wantConsumed:   false,
+			wantAvailable:  0,
+			wantScheduled:  3,
+			wantMinBackoff: 30 * time.Millisecond,
+			wantMaxBackoff: 45 * time.Millisecond,
+		},
+		{
+			name:           "no retries configured",
+			conflicts:      1,
+			maxRetries:     1,
+			wantConsumed:   false,
+			wantAvailable:  2,
+			wantScheduled:  1,
+			wantMinBackoff: 0,
+			wantMaxBackoff: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj()}
+			args := defaultArgs()
+			args.SlotUpdateMaxRetries = tt.maxRetries
+			s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 3))
+			start := time.Now()
+			fakeClock := clocktesting.NewFakeClock(start)
+			s.clock = fakeClock
+			hint := createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+				Rank: 1,
+				ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+					{
+						Namespace:          "default",
+						ReplicaSetName:     "web",
+						TargetDistribution: map[string]int{"node-a": 3},
+						AvailableSlots:     map[string]int{"node-a": 3},
+					},
+				},
+			})
+
+			// Another scheduler consumes a slot and updates the hint status between each Get and Patch
+			fakeClient := s.client.(*deschedulerfake.Clientset)
+			gvr := deschedulerv1alpha1.SchemeGroupVersion.WithResource("schedulinghints")
+			conflicts := tt.conflicts
+			fakeClient.PrependReactor("patch", "schedulinghints", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if conflicts == 0 {
+					return false, nil, nil
+				}
+				conflicts--
+				obj, err := fakeClient.Tracker().Get(gvr, "", hint.Name)
+				if err != nil {
+					return true, nil, err
+				}
+				concurrent := obj.(*deschedulerv1alpha1.SchedulingHint).DeepCopy()
+				movement := &concurrent.Spec.Solutions[0].ReplicaSetMovements[0]
+				movement.SetSlots("node-a", movement.AvailableSlotsFor("node-a")-1, movement.ScheduledCountFor("node-a")+1)
+				concurrent.Status.Phase = deschedulerv1alpha1.SchedulingHintPhaseActive
+				if err := fakeClient.Tracker().Update(gvr, concurrent, ""); err != nil {
+					return true, nil, err
+				}
+				return true, nil, apierrors.NewConflict(gvr.GroupResource(), hint.Name, fmt.Errorf("the object has been modified"))
+			})
+
+			if _, got := s.tryConsumeSlot(ctx, hint, 0, "default/web", "node-a"); got != tt.wantConsumed {
+				t.Errorf("tryConsumeSlot() = %v, want %v", got, tt.wantConsumed)
+			}
+
+			// Retries wait for exponentially growing, jittered backoff periods
+			if backoff := fakeClock.Since(start); backoff < tt.wantMinBackoff || backoff > tt.wantMaxBackoff {
+				t.Errorf("backoff between retries = %v, want between %v and %v", backoff, tt.wantMinBackoff, tt.wantMaxBackoff)
+			}
+
+			got, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get scheduling hint: %v", err)
+			}
+			movement := got.Spec.Solutions[0].ReplicaSetMovements[0]
+			if movement.AvailableSlots["node-a"] != tt.wantAvailable || movement.ScheduledCount["node-a"] != tt.wantScheduled {
+				t.Errorf("slots = (%d available, %d scheduled), want (%d, %d)",
+					movement.AvailableSlots["node-a"], movement.ScheduledCount["node-a"], tt.wantAvailable, tt.wantScheduled)
+			}
+			// The patch only touches the slot counters, leaving concurrent changes to other fields intact
+			if got.Status.Phase != deschedulerv1alpha1.SchedulingHintPhaseActive {
+				t.Errorf("status phase = %q, want concurrent update %q to be preserved", got.Status.Phase, deschedulerv1alpha1.SchedulingHintPhaseActive)
+			}
+		})
+	}
+}
+
+func TestGetSchedulingHint(t *testing.T) {
+	topSolution := deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 3},
+				AvailableSlots:     map[string]int{"node-a": 3},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		createHint   bool
+		solutions    []deschedulerv1alpha1.OptimizationSolution
+		getErr       error
+		wantHint     bool
+		wantSolution *deschedulerv1alpha1.OptimizationSolution
+		wantErr      bool
+	}{
+		{
+			name:         "hint found",
+			createHint:   true,
+			solutions:    []deschedulerv1alpha1.OptimizationSolution{topSolution, {Rank: 2}},
+			wantHint:     true,
+			wantSolution: &topSolution,
+		},
+		{
+			name:       "hint missing",
+			createHint: false,
+		},
+		{
+			name:       "hint without solutions",
+			createHint: true,
+			wantErr:    true,
+		},
+		{
+			name:       "hint lookup fails",
+			createHint: true,
+			solutions:  []deschedulerv1alpha1.OptimizationSolution{topSolution},
+			getErr:     fmt.Errorf("connection refused"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj()}
+			s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 3))
+			if tt.createHint {
+				createHint(ctx, t, s, tt.solutions...)
+			}
+			if tt.getErr != nil {
+				s.client.(*deschedulerfake.Clientset).PrependReactor("get", "schedulinghints", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, tt.getErr
+				})
+			}
+
+			hint, solution, err := s.getSchedulingHint(ctx)
+			if gotErr := err != nil; gotErr != tt.wantErr {
+				t.Fatalf("getSchedulingHint() error = %v, want error %v", err, tt.wantErr)
+			}
+			if got := hint != nil; got != tt.wantHint {
+				t.Errorf("getSchedulingHint() found hint = %v, want %v", got, tt.wantHint)
+			}
+			if diff := cmp.Diff(tt.wantSolution, solution); diff != "" {
+				t.Errorf("unexpected solution (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetSchedulingHintExpiration(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		expirationTime *metav1.Time
+		phase          deschedulerv1alpha1.SchedulingHintPhase
+		availableSlots map[string]int
+		wantHint       bool
+	}{
+		{
+			name:           "active hint before expiration",
+			expirationTime: &metav1.Time{Time: now.Add(time.Hour)},
+			phase:          deschedulerv1alpha1.SchedulingHintPhaseActive,
+			wantHint:       true,
+		},
+		{
+			name:     "hint without expiration time",
+			wantHint: true,
+		},
+		{
+			name:           "expiration time in the past",
+			expirationTime: &metav1.Time{Time: now.Add(-time.Minute)},
+			phase:          deschedulerv1alpha1.SchedulingHintPhaseActive,
+			wantHint:       false,
+		},
+		{
+			name:           "expired phase",
+			expirationTime: &metav1.Time{Time: now.Add(time.Hour)},
+			phase:          deschedulerv1alpha1.SchedulingHintPhaseExpired,
+			wantHint:       false,
+		},
+		{
+			name:           "negative available slots",
+			expirationTime: &metav1.Time{Time: now.Add(time.Hour)},
+			phase:          deschedulerv1alpha1.SchedulingHintPhaseActive,
+			availableSlots: map[string]int{"node-a": -1},
+			wantHint:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj()}
+			s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 3))
+			s.clock = clocktesting.NewFakeClock(now)
+
+			hint := createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+				Rank: 1,
+				ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+					{Namespace: "default", ReplicaSetName: "web", AvailableSlots: tt.availableSlots},
+				},
+			})
+			hint.Spec.ExpirationTime = tt.expirationTime
+			hint.Status.Phase = tt.phase
+			if _, err := s.client.DeschedulerV1alpha1().SchedulingHints().Update(ctx, hint, metav1.UpdateOptions{}); err != nil {
+				t.Fatalf("failed to update scheduling hint: %v", err)
+			}
+
+			gotHint, gotSolution, err := s.getSchedulingHint(ctx)
+			if err != nil {
+				t.Fatalf("getSchedulingHint() unexpected error: %v", err)
+			}
+			if got := gotHint != nil && gotSolution != nil; got != tt.wantHint {
+				t.Errorf("getSchedulingHint() returned hint = %v, want %v", got, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestFingerprintNodeInputs(t *testing.T) {
+	makeNodes := func(cpu, zone string) []*v1.Node {
+		return []*v1.Node{
+			st.MakeNode().Name("node-a").Label("topology.kubernetes.io/zone", zone).
+				Capacity(map[v1.ResourceName]string{v1.ResourceCPU: cpu, v1.ResourceMemory: "8Gi"}).Obj(),
+		}
+	}
+	replicaSets := []*appsv1.ReplicaSet{makeReplicaSet("default", "web", 3)}
+	small := makeNodes("4", "zone-1")
+	large := makeNodes("16", "zone-1")
+	otherZone := makeNodes("4", "zone-2")
+
+	tests := []struct {
+		name          string
+		nodeResources bool
+		nodeLabels    []string
+		wantSpec      string
+		// Whether the fingerprint differs for nodes with different capacity or zone
+		wantResourcesDiffer bool
+		wantLabelsDiffer    bool
+	}{
+		{
+			name:     "default formula",
+			wantSpec: "nodes:node-a|replicasets:default/web=3",
+		},
+		{
+			name:                "node resources",
+			nodeResources:       true,
+			wantSpec:            "nodes:node-a{cpu=4000,memory=8589934592}|replicasets:default/web=3",
+			wantResourcesDiffer: true,
+		},
+		{
+			name:             "node labels",
+			nodeLabels:       []string{"topology.kubernetes.io/zone"},
+			wantSpec:         "nodes:node-a{topology.kubernetes.io/zone=zone-1}|replicasets:default/web=3",
+			wantLabelsDiffer: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args := defaultArgs()
+			args.FingerprintNodeResources = tt.nodeResources
+			args.FingerprintNodeLabels = tt.nodeLabels
+			s := &MultiObjectiveScheduler{logger: klog.Background(), args: args}
+
+			got := s.calculateClusterFingerprintFromReplicaSets(small, replicaSets)
+			hash := sha256.Sum256([]byte(tt.wantSpec))
+			if want := fmt.Sprintf("%x", hash)[:16]; got != want {
+				t.Errorf("calculateClusterFingerprintFromReplicaSets() = %q, want %q", got, want)
+			}
+			if differ := s.calculateClusterFingerprintFromReplicaSets(large, replicaSets) != got; differ != tt.wantResourcesDiffer {
+				t.Errorf("fingerprint differs for different node capacity = %v, want %v", differ, tt.wantResourcesDiffer)
+			}
+			if differ := s.calculateClusterFingerprintFromReplicaSets(otherZone, replicaSets) != got; differ != tt.wantLabelsDiffer {
+				t.Errorf("fingerprint differs for different node zone = %v, want %v", differ, tt.wantLabelsDiffer)
+			}
+		})
+	}
+}
+
+func TestDryRun(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+	}
+	nodeInfos := []*framework.NodeInfo{makeNodeInfo(nodes[0]), makeNodeInfo(nodes[1])}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+
+	args := defaultArgs()
+	args.DryRun = true
+	args.FilterNonTargetNodes = true
+	args.StrictHint = true
+	s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 3), pod)
+	createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 2},
+				AvailableSlots:     map[string]int{"node-a": 2},
+			},
+		},
+	})
+	fakeClient := s.client.(*deschedulerfake.Clientset)
+	fakeClient.ClearActions()
+
+	state := framework.NewCycleState()
+	for _, nodeInfo := range nodeInfos {
+		if status := s.Filter(ctx, state, pod, nodeInfo); !status.IsSuccess() {
+			t.Errorf("Filter(%s) = %v, want success in dry run mode", nodeInfo.Node().Name, status)
+		}
+	}
+	if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+		t.Fatalf("PreScore() unexpected status: %v", status)
+	}
+	scores := make(framework.NodeScoreList, 0, len(nodes))
+	for _, node := range nodes {
+		score, status := s.Score(ctx, state, pod, node.Name)
+		if !status.IsSuccess() {
+			t.Fatalf("Score(%s) unexpected status: %v", node.Name, status)
+		}
+		scores = append(scores, framework.NodeScore{Name: node.Name, Score: score})
+	}
+	if status := s.NormalizeScore(ctx, state, pod, scores); !status.IsSuccess() {
+		t.Fatalf("NormalizeScore() unexpected status: %v", status)
+	}
+	for _, score := range scores {
+		if score.Score != MinNodeScore {
+			t.Errorf("score(%s) = %d, want %d in dry run mode", score.Name, score.Score, MinNodeScore)
+		}
+	}
+	if status := s.Reserve(ctx, state, pod, "node-a"); !status.IsSuccess() {
+		t.Fatalf("Reserve() unexpected status: %v", status)
+	}
+	if status := s.PreBind(ctx, state, pod, "node-a"); !status.IsSuccess() {
+		t.Fatalf("PreBind() unexpected status: %v", status)
+	}
+
+	// The hint was looked up but never modified
+	for _, action := range fakeClient.Actions() {
+		if action.GetVerb() != "get" {
+			t.Errorf("unexpected %s of %s in dry run mode", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+}
+
+func TestGetSchedulingHintStaleNodes(t *testing.T) {
+	solution := func(targets map[string]int) deschedulerv1alpha1.OptimizationSolution {
+		return deschedulerv1alpha1.OptimizationSolution{
+			Rank: 1,
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					Namespace:          "default",
+					ReplicaSetName:     "web",
+					TargetDistribution: targets,
+					AvailableSlots:     targets,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		clusterNodes []string
+		solutions    []deschedulerv1alpha1.OptimizationSolution
+		wantHint     bool
+	}{
+		{
+			name:         "all hint nodes exist",
+			clusterNodes: []string{"node-a", "node-b"},
+			solutions:    []deschedulerv1alpha1.OptimizationSolution{solution(map[string]int{"node-a": 2, "node-b": 1})},
+			wantHint:     true,
+		},
+		{
+			name:         "target node removed",
+			clusterNodes: []string{"node-a", "node-b", "node-c"},
+			solutions:    []deschedulerv1alpha1.OptimizationSolution{solution(map[string]int{"node-a": 1, "node-c": 2})},
+			wantHint:     false,
+		},
+		{
+			name:         "target node of a lower ranked solution removed",
+			clusterNodes: []string{"node-a", "node-b"},
+			solutions: []deschedulerv1alpha1.OptimizationSolution{
+				solution(map[string]int{"node-a": 2}),
+				solution(map[string]int{"node-c": 2}),
+			},
+			wantHint: false,
+		},
+		{
+			name:         "recorded cluster node removed",
+			clusterNodes: []string{"node-a", "node-b", "node-c"},
+			solutions:    []deschedulerv1alpha1.OptimizationSolution{solution(map[string]int{"node-a": 2})},
+			wantHint:     false,
+		},
+		{
+			name:         "node joined after the hint was computed",
+			clusterNodes: []string{"node-a"},
+			solutions:    []deschedulerv1alpha1.OptimizationSolution{solution(map[string]int{"node-a": 2})},
+			wantHint:     false,
+		},
+		{
+			name:         "zero target on removed node is ignored",
+			clusterNodes: []string{"node-a", "node-b"},
+			solutions:    []deschedulerv1alpha1.OptimizationSolution{solution(map[string]int{"node-a": 2, "node-c": 0})},
+			wantHint:     true,
+		},
+		{
+			name:         "slots on removed node",
+			clusterNodes: []string{"node-a", "node-b"},
+			solutions: []deschedulerv1alpha1.OptimizationSolution{{
+				Rank: 1,
+				ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+					{
+						Namespace:          "default",
+						ReplicaSetName:     "web",
+						TargetDistribution: map[string]int{"node-a": 2},
+						AvailableSlots:     map[string]int{"node-a": 2, "node-c": 1},
+					},
+				},
+			}},
+			wantHint: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{
+				st.MakeNode().Name("node-a").Obj(),
+				st.MakeNode().Name("node-b").Obj(),
+			}
+			s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 3))
+			hint := createHint(ctx, t, s, tt.solutions...)
+			hint.Spec.ClusterNodes = tt.clusterNodes
+			if _, err := s.client.DeschedulerV1alpha1().SchedulingHints().Update(ctx, hint, metav1.UpdateOptions{}); err != nil {
+				t.Fatalf("failed to update scheduling hint: %v", err)
+			}
+
+			mismatches := counterValue(t, hintNodeMismatchTotal)
+			gotHint, gotSolution, err := s.getSchedulingHint(ctx)
+			if err != nil {
+				t.Fatalf("getSchedulingHint() unexpected error: %v", err)
+			}
+			if got := gotHint != nil && gotSolution != nil; got != tt.wantHint {
+				t.Errorf("getSchedulingHint() returned hint = %v, want %v", got, tt.wantHint)
+			}
+			wantMismatches := 0.0
+			if !tt.wantHint {
+				wantMismatches = 1
+			}
+			if got := counterValue(t, hintNodeMismatchTotal) - mismatches; got != wantMismatches {
+				t.Errorf("hint_node_mismatch_total increased by %v, want %v", got, wantMismatches)
+			}
+		})
+	}
+}
+
+func TestGetSchedulingHintClusterNodesMismatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var buf bytes.Buffer
+	logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(3), textlogger.Output(&buf)))
+	ctx = klog.NewContext(ctx, logger)
+
+	nodes := []*v1.Node{
+		st.MakeNode().Name("control-plane").Label("node-role.kubernetes.io/control-plane", "").Obj(),
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+	}
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 2))
+	hint := createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 2},
+				AvailableSlots:     map[string]int{"node-a": 2},
+			},
+		},
+	})
+	update := func(clusterNodes []string) {
+		t.Helper()
+		hint.Spec.ClusterNodes = clusterNodes
+		var err error
+		if hint, err = s.client.DeschedulerV1alpha1().SchedulingHints().Update(ctx, hint, metav1.UpdateOptions{}); err != nil {
+			t.Fatalf("failed to update scheduling hint: %v", err)
+		}
+	}
+
+	// Control-plane nodes are never recorded by the descheduler
+	update([]string{"node-a", "node-b"})
+	if gotHint, _, err := s.getSchedulingHint(ctx); err != nil || gotHint == nil {
+		t.Fatalf("getSchedulingHint() = (%v, %v), want hint matching the worker nodes", gotHint, err)
+	}
+
+	// The hint records the fingerprint of the cluster state it was computed for
+	buf.Reset()
+	hint.Spec.ClusterFingerprint = "recorded"
+	update([]string{"node-a"})
+	gotHint, _, err := s.getSchedulingHint(ctx)
+	if err != nil {
+		t.Fatalf("getSchedulingHint() unexpected error: %v", err)
+	}
+	if gotHint != nil {
+		t.Errorf("getSchedulingHint() returned hint not recording node-b")
+	}
+	fingerprint, err := s.getClusterFingerprint()
+	if err != nil {
+		t.Fatalf("getClusterFingerprint() unexpected error: %v", err)
+	}
+	for _, want := range []string{
+		`does not match the current cluster nodes`,
+		`fingerprint="` + fingerprint + `"`,
+		`hintFingerprint="recorded"`,
+		`unrecordedNodes=["node-b"]`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("mismatch log does not contain %s:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestHintNamePrefix(t *testing.T) {
+	tests := []struct {
+		name           string
+		hintNamePrefix string
+		hintName       string
+		wantHint       bool
+	}{
+		{
+			name:           "default prefix",
+			hintNamePrefix: cfgv1.DefaultMultiObjectiveHintNamePrefix,
+			hintName:       "multiobjective-hints-",
+			wantHint:       true,
+		},
+		{
+			name:           "custom prefix",
+			hintNamePrefix: "tenant-a-hints-",
+			hintName:       "tenant-a-hints-",
+			wantHint:       true,
+		},
+		{
+			name:           "hint written with another instance's prefix",
+			hintNamePrefix: "tenant-a-hints-",
+			hintName:       "tenant-b-hints-",
+			wantHint:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			args := defaultArgs()
+			args.HintNamePrefix = tt.hintNamePrefix
+			nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj()}
+			s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 3))
+
+			fingerprint, err := s.getClusterFingerprint()
+			if err != nil {
+				t.Fatalf("getClusterFingerprint() unexpected error: %v", err)
+			}
+			if got, want := s.generateHintName(fingerprint), tt.hintNamePrefix+fingerprint; got != want {
+				t.Errorf("generateHintName() = %q, want %q", got, want)
+			}
+
+			hint := &deschedulerv1alpha1.SchedulingHint{
+				ObjectMeta: metav1.ObjectMeta{Name: tt.hintName + fingerprint},
+				Spec: deschedulerv1alpha1.SchedulingHintSpec{
+					ClusterFingerprint: fingerprint,
+					Solutions:          []deschedulerv1alpha1.OptimizationSolution{{Rank: 1}},
+				},
+			}
+			if _, err := s.client.DeschedulerV1alpha1().SchedulingHints().Create(ctx, hint, metav1.CreateOptions{}); err != nil {
+				t.Fatalf("failed to create scheduling hint: %v", err)
+			}
+
+			gotHint, _, err := s.getSchedulingHint(ctx)
+			if err != nil {
+				t.Fatalf("getSchedulingHint() unexpected error: %v", err)
+			}
+			if got := gotHint != nil; got != tt.wantHint {
+				t.Errorf("getSchedulingHint() found hint = %v, want %v", got, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestFallbackToLowerRankedSolution(t *testing.T) {
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+		st.MakeNode().Name("node-c").Obj(),
+	}
+	nodeInfos := make([]*framework.NodeInfo, len(nodes))
+	for i, node := range nodes {
+		nodeInfos[i] = makeNodeInfo(node)
+	}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+	solution := func(rank int, node string, slots int) deschedulerv1alpha1.OptimizationSolution {
+		return deschedulerv1alpha1.OptimizationSolution{
+			Rank: rank,
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					Namespace:          "default",
+					ReplicaSetName:     "web",
+					TargetDistribution: map[string]int{node: 2},
+					AvailableSlots:     map[string]int{node: slots},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		solutions      []deschedulerv1alpha1.OptimizationSolution
+		wantSolution   int
+		wantTargetNode string
+		wantAvailable  int
+	}{
+		{
+			name: "top solution with slots is used",
+			solutions: []deschedulerv1alpha1.OptimizationSolution{
+				solution(1, "node-a", 2),
+				solution(2, "node-b", 2),
+			},
+			wantSolution:   0,
+			wantTargetNode: "node-a",
+			wantAvailable:  1,
+		},
+		{
+			name: "exhausted top solution falls back to the best ranked solution with slots",
+			solutions: []deschedulerv1alpha1.OptimizationSolution{
+				solution(1, "node-a", 0),
+				solution(3, "node-c", 2),
+				solution(2, "node-b", 1),
+			},
+			wantSolution:   2,
+			wantTargetNode: "node-b",
+			wantAvailable:  0,
+		},
+		{
+			name: "all solutions exhausted keeps the top solution",
+			solutions: []deschedulerv1alpha1.OptimizationSolution{
+				solution(1, "node-a", 0),
+				solution(2, "node-b", 0),
+			},
+			wantSolution:   0,
+			wantTargetNode: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 3))
+			hint := createHint(ctx, t, s, tt.solutions...)
+
+			state := framework.NewCycleState()
+			if _, status := s.PreFilter(ctx, state, pod); !status.IsSuccess() && !status.IsSkip() {
+				t.Fatalf("PreFilter() unexpected status: %v", status)
+			}
+			if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+				t.Fatalf("PreScore() unexpected status: %v", status)
+			}
+			cycleState := readCycleState(state)
+			if cycleState.SolutionIndex != tt.wantSolution || cycleState.TargetNode != tt.wantTargetNode {
+				t.Fatalf("selected (solution %d, target %q), want (solution %d, target %q)",
+					cycleState.SolutionIndex, cycleState.TargetNode, tt.wantSolution, tt.wantTargetNode)
+			}
+			if tt.wantTargetNode == "" {
+				return
+			}
+
+			// The slot is consumed from the solution the pod was placed with
+			if status := s.Reserve(ctx, state, pod, tt.wantTargetNode); !status.IsSuccess() {
+				t.Fatalf("Reserve() unexpected status: %v", status)
+			}
+			got, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
+			if err != nil {
+				t.Fatalf("failed to get scheduling hint: %v", err)
+			}
+			movement := got.Spec.Solutions[tt.wantSolution].ReplicaSetMovements[0]
+			if movement.AvailableSlots[tt.wantTargetNode] != tt.wantAvailable {
+				t.Errorf("available slots on %s = %d, want %d",
+					tt.wantTargetNode, movement.AvailableSlots[tt.wantTargetNode], tt.wantAvailable)
+			}
+		})
+	}
+}
+
+func TestNoSchedulingCycleLogsAtDefaultVerbosity(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Every log line of a regular scheduling cycle is per pod or per node, so none may be logged at V(0)
+	var buf bytes.Buffer
+	logger := textlogger.NewLogger(textlogger.NewConfig(textlogger.Verbosity(0), textlogger.Output(&buf)))
+	ctx = klog.NewContext(ctx, logger)
+
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+		st.MakeNode().Name("node-c").Obj(),
+	}
+	nodeInfos := make([]*framework.NodeInfo, len(nodes))
+	for i, node := range nodes {
+		nodeInfos[i] = makeNodeInfo(node)
+	}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+
+	args := defaultArgs()
+	args.FilterNonTargetNodes = true
+	s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 3))
+	createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+				AvailableSlots:     map[string]int{"node-a": 2, "node-b": 1},
+			},
+		},
+	})
+
+	state := framework.NewCycleState()
+	if _, status := s.PreFilter(ctx, state, pod); !status.IsSuccess() {
+		t.Fatalf("PreFilter() unexpected status: %v", status)
+	}
+	for _, nodeInfo := range nodeInfos {
+		s.Filter(ctx, state, pod, nodeInfo)
+	}
+	if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+		t.Fatalf("PreScore() unexpected status: %v", status)
+	}
+	scores := make(framework.NodeScoreList, 0, len(nodes))
+	for _, node := range nodes {
+		score, status := s.Score(ctx, state, pod, node.Name)
+		if !status.IsSuccess() {
+			t.Fatalf("Score(%s) unexpected status: %v", node.Name, status)
+		}
+		scores = append(scores, framework.NodeScore{Name: node.Name, Score: score})
+	}
+	s.NormalizeScore(ctx, state, pod, scores)
+	if status := s.Reserve(ctx, state, pod, "node-a"); !status.IsSuccess() {
+		t.Fatalf("Reserve() unexpected status: %v", status)
+	}
+	s.Unreserve(ctx, state, pod, "node-a")
+
+	if buf.Len() > 0 {
+		t.Errorf("unexpected logs at default verbosity:\n%s", buf.String())
+	}
+}
+
+func TestGetSchedulingHintMaxWeightedScore(t *testing.T) {
+	solution := deschedulerv1alpha1.OptimizationSolution{
+		Rank:          1,
+		WeightedScore: 0.3,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 2},
+				AvailableSlots:     map[string]int{"node-a": 2},
+			},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		maxWeightedScore *float64
+		wantHint         bool
+	}{
+		{
+			name:     "no threshold",
+			wantHint: true,
+		},
+		{
+			name:             "top solution below threshold",
+			maxWeightedScore: ptr.To(0.5),
+			wantHint:         true,
+		},
+		{
+			name:             "top solution at threshold",
+			maxWeightedScore: ptr.To(0.3),
+			wantHint:         true,
+		},
+		{
+			name:             "top solution above threshold",
+			maxWeightedScore: ptr.To(0.1),
+			wantHint:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj()}
+			args := defaultArgs()
+			args.MaxWeightedScore = tt.maxWeightedScore
+			s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 2))
+			createHint(ctx, t, s, solution)
+
+			gotHint, gotSolution, err := s.getSchedulingHint(ctx)
+			if err != nil {
+				t.Fatalf("getSchedulingHint() unexpected error: %v", err)
+			}
+			if got := gotHint != nil && gotSolution != nil; got != tt.wantHint {
+				t.Errorf("getSchedulingHint() returned hint = %v, want %v", got, tt.wantHint)
+			}
+		})
+	}
+}
+
+func TestMaxWeightedScoreSelectsSolution(t *testing.T) {
+	nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj(), st.MakeNode().Name("node-b").Obj()}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	solution := func(rank int, weightedScore float64, nodeName string) deschedulerv1alpha1.OptimizationSolution {
+		return deschedulerv1alpha1.OptimizationSolution{
+			Rank:          rank,
+			WeightedScore: weightedScore,
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					Namespace:          "default",
+					ReplicaSetName:     "web",
+					TargetDistribution: map[string]int{nodeName: 2},
+					AvailableSlots:     map[string]int{nodeName: 2},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		maxWeightedScore *float64
+		// wantTarget is the only node passing Filter, or "" when the hint is not used
+		wantTarget string
+	}{
+		{
+			name:       "no threshold",
+			wantTarget: "node-a",
+		},
+		{
+			name:             "top solution above threshold",
+			maxWeightedScore: ptr.To(0.2),
+			wantTarget:       "node-b",
+		},
+		{
+			name:             "all solutions above threshold",
+			maxWeightedScore: ptr.To(0.05),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			args := defaultArgs()
+			args.FilterNonTargetNodes = true
+			args.MaxWeightedScore = tt.maxWeightedScore
+			s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 2))
+			createHint(ctx, t, s, solution(1, 0.3, "node-a"), solution(2, 0.1, "node-b"))
+			pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+
+			state := framework.NewCycleState()
+			s.PreFilter(ctx, state, pod)
+			for _, node := range nodes {
+				status := s.Filter(ctx, state, pod, makeNodeInfo(node))
+				if got, want := status.IsSuccess(), tt.wantTarget == "" || node.Name == tt.wantTarget; got != want {
+					t.Errorf("Filter(%s) schedulable = %v, want %v", node.Name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGetSchedulingHintTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The API server never answers, so only the lookup timeout ends the request
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj()}
+	args := defaultArgs()
+	args.HintLookupTimeoutMilliseconds = 50
+	client, err := versioned.NewForConfig(&restclient.Config{Host: server.URL})
+	if err != nil {
+		t.Fatalf("failed to create clientset: %v", err)
+	}
+	s := newTestSchedulerWithClient(ctx, t, args, client, nodes, makeReplicaSet("default", "web", 2))
+
+	start := time.Now()
+	hint, solution, err := s.getSchedulingHint(ctx)
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("getSchedulingHint() took %v, want it bounded by the lookup timeout", elapsed)
+	}
+	if err == nil {
+		t.Errorf("getSchedulingHint() expected a timeout error, got none")
+	}
+	if hint != nil || solution != nil {
+		t.Errorf("getSchedulingHint() returned a hint for a lookup that timed out")
+	}
+
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+)
+
+// slotConfirmationInterval is how often a waiting pod's hint is re-read to confirm its slot consumption
+const slotConfirmationInterval = 100 * time.Millisecond
+
+// Permit implements the Permit extension point. When SlotConfirmationTimeoutSeconds is set, a pod placed
+// on its hint's target node only binds once the slot consumed in Reserve is observed on a re-read of the
+// hint. Pods whose consumption is not observed in time are rejected and rescheduled, as are pods whose
+// slot was not consumed when Filter confines pods to the hint's slots
+func (s *MultiObjectiveScheduler) Permit(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) (*framework.Status, time.Duration) {
+	cycleState := readCycleState(state)
+	if s.args.SlotConfirmationTimeoutSeconds <= 0 || cycleState == nil || !cycleState.HasHint || nodeName != cycleState.TargetNode {
+		return nil, 0
+	}
+	if !cycleState.SlotConsumed {
+		// Without a filter flag a pod is placed on a target node whose slot it could not consume
+		if !s.filtersNodes() {
+			return nil, 0
+		}
+		s.logger.V(3).Info("Slot on target node was not consumed - rejecting pod",
+			"pod", klog.KObj(pod), "node", nodeName, "hint", cycleState.Hint.Name)
+		return framework.NewStatus(framework.Unschedulable,
+			fmt.Sprintf("slot on node %s was not consumed in scheduling hint %s", nodeName, cycleState.Hint.Name)), 0
+	}
+
+	if s.slotConsumptionObserved(ctx, cycleState, nodeName) {
+		return nil, 0
+	}
+
+	timeout := time.Duration(s.args.SlotConfirmationTimeoutSeconds) * time.Second
+	s.logger.V(3).Info("Waiting for slot consumption to be observed before binding",
+		"pod", klog.KObj(pod), "node", nodeName, "hint", cycleState.Hint.Name, "timeout", timeout)
+	go s.confirmSlotConsumption(pod, cycleState, nodeName, timeout)
+	return framework.NewStatus(framework.Wait), timeout
+}
+
+// confirmSlotConsumption re-reads the hint until the pod's slot consumption is observed and then allows
+// the waiting pod to bind. It gives up when the timeout expires, by which time the framework rejects the
+// pod, or when the scheduler shuts down
+func (s *MultiObjectiveScheduler) confirmSlotConsumption(pod *v1.Pod, cycleState *MultiObjectiveState, nodeName string, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(wait.ContextForChannel(s.stopCh), timeout)
+	defer cancel()
+
+	err := wait.PollUntilContextCancel(ctx, slotConfirmationInterval, false, func(ctx context.Context) (bool, error) {
+		if !s.slotConsumptionObserved(ctx, cycleState, nodeName) {
+			return false, nil
+		}
+		// The pod only becomes a waiting pod once Permit has returned
+		waitingPod := s.handle.GetWaitingPod(pod.UID)
+		if waitingPod == nil {
+			return false, nil
+		}
+		waitingPod.Allow(Name)
+		return true, nil
+	})
+	if err != nil {
+		s.logger.V(3).Info("Slot consumption not observed before timeout - pod will be rescheduled",
+			"pod", klog.KObj(pod), "node", nodeName, "hint", cycleState.Hint.Name)
+	}
+}
+
+// slotConsumptionObserved returns whether a fresh read of the hint reflects the slot consumed in Reserve
+func (s *MultiObjectiveScheduler) slotConsumptionObserved(ctx context.Context, cycleState *MultiObjectiveState, nodeName string) bool {
+	hint, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, cycleState.Hint.Name, metav1.GetOptions{})
+	if err != nil {
+		s.logger.V(4).Info("Cannot fetch hint to confirm slot consumption",
+			"hint", cycleState.Hint.Name, "error", err.Error())
+		return false
+	}
+	if len(hint.Spec.Solutions) <= cycleState.SolutionIndex {
+		return false
+	}
+	movement := findReplicaSetMovement(&hint.Spec.Solutions[cycleState.SolutionIndex], cycleState.RSKey)
+	return movement != nil && movement.ScheduledCountFor(nodeName) >= cycleState.ScheduledCount
+}
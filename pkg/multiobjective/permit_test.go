@@ -0,0 +1,179 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clienttesting "k8s.io/client-go/testing"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+	tf "k8s.io/kubernetes/pkg/scheduler/testing/framework"
+
+	"sigs.k8s.io/scheduler-plugins/apis/config"
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+	deschedulerfake "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/fake"
+)
+
+// newPermitTestFramework returns a framework running the plugin as its Permit plugin, so that waiting
+// pods are tracked by the framework
+func newPermitTestFramework(ctx context.Context, t *testing.T, args *config.MultiObjectiveArgs, nodes []*v1.Node, objs ...runtime.Object) (framework.Framework, *MultiObjectiveScheduler) {
+	t.Helper()
+	var s *MultiObjectiveScheduler
+	newPlugin := func(ctx context.Context, _ runtime.Object, h framework.Handle) (framework.Plugin, error) {
+		p, err := newWithClient(ctx, args, h, deschedulerfake.NewSimpleClientset())
+		if err != nil {
+			return nil, err
+		}
+		s = p.(*MultiObjectiveScheduler)
+		return s, nil
+	}
+	fr, informerFactory := newTestFrameworkWithPlugins(ctx, t, []tf.RegisterPluginFunc{tf.RegisterPermitPlugin(Name, newPlugin)}, nodes, objs...)
+	informerFactory.Start(ctx.Done())
+	informerFactory.WaitForCacheSync(ctx.Done())
+	return fr, s
+}
+
+func TestPermit(t *testing.T) {
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+	}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").UID("web-0").OwnerReference("web", rsOwner).Obj()
+	solution := deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+				AvailableSlots:     map[string]int{"node-a": 2, "node-b": 1},
+			},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		timeoutSeconds int64
+		noHint         bool
+		// staleReads is the number of hint reads that do not yet reflect the consumed slot, -1 for all
+		staleReads  int
+		skipReserve bool
+		// filterNodes sets FilterNonTargetNodes, which confines pods to the hint's slots
+		filterNodes bool
+		wantWait    bool
+		wantCode    framework.Code
+	}{
+		{
+			name:           "no hint passes through",
+			timeoutSeconds: 1,
+			noHint:         true,
+			wantCode:       framework.Success,
+		},
+		{
+			name:           "disabled confirmation passes through",
+			timeoutSeconds: 0,
+			staleReads:     -1,
+			wantCode:       framework.Success,
+		},
+		{
+			name:           "observed consumption allows immediately",
+			timeoutSeconds: 1,
+			wantCode:       framework.Success,
+		},
+		{
+			name:           "delayed consumption allows after waiting",
+			timeoutSeconds: 5,
+			staleReads:     2,
+			wantWait:       true,
+			wantCode:       framework.Success,
+		},
+		{
+			name:           "unconsumed slot on target node is rejected",
+			timeoutSeconds: 1,
+			skipReserve:    true,
+			filterNodes:    true,
+			wantCode:       framework.Unschedulable,
+		},
+		{
+			name:           "unconsumed slot on target node passes through in score-only mode",
+			timeoutSeconds: 1,
+			skipReserve:    true,
+			wantCode:       framework.Success,
+		},
+		{
+			name:           "unobserved consumption is rejected on timeout",
+			timeoutSeconds: 1,
+			staleReads:     -1,
+			wantWait:       true,
+			wantCode:       framework.Unschedulable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			args := defaultArgs()
+			args.SlotConfirmationTimeoutSeconds = tt.timeoutSeconds
+			args.FilterNonTargetNodes = tt.filterNodes
+			fr, s := newPermitTestFramework(ctx, t, args, nodes, makeReplicaSet("default", "web", 3))
+			var stale *deschedulerv1alpha1.SchedulingHint
+			if !tt.noHint {
+				stale = createHint(ctx, t, s, solution)
+			}
+
+			state := framework.NewCycleState()
+			nodeInfos := []*framework.NodeInfo{makeNodeInfo(nodes[0]), makeNodeInfo(nodes[1])}
+			if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() && !status.IsSkip() {
+				t.Fatalf("PreScore() unexpected status: %v", status)
+			}
+			if !tt.skipReserve {
+				if status := s.Reserve(ctx, state, pod, "node-a"); !status.IsSuccess() {
+					t.Fatalf("Reserve() unexpected status: %v", status)
+				}
+			}
+
+			// Reads of the hint from here on may not yet reflect the slot consumed in Reserve
+			staleReads := tt.staleReads
+			s.client.(*deschedulerfake.Clientset).PrependReactor("get", "schedulinghints", func(action clienttesting.Action) (bool, runtime.Object, error) {
+				if stale == nil || staleReads == 0 {
+					return false, nil, nil
+				}
+				staleReads--
+				return true, stale.DeepCopy(), nil
+			})
+
+			status := fr.RunPermitPlugins(ctx, state, pod, "node-a")
+			if got := status.IsWait(); got != tt.wantWait {
+				t.Fatalf("RunPermitPlugins() status = %v, want wait %v", status, tt.wantWait)
+			}
+			if tt.wantWait {
+				status = fr.WaitOnPermit(ctx, pod)
+			}
+			if got := status.Code(); got != tt.wantCode {
+				t.Errorf("Permit outcome = %v, want %v", status, tt.wantCode)
+			}
+		})
+	}
+}
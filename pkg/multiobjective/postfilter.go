@@ -0,0 +1,158 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+)
+
+// alternateSolution is a hint solution recorded by PostFilter for a pod's next scheduling cycle
+type alternateSolution struct {
+	hintName      string
+	solutionIndex int
+}
+
+// alternateSolutions records the alternate solutions of pods waiting for their next scheduling cycle
+type alternateSolutions struct {
+	lock      sync.Mutex
+	solutions map[types.UID]alternateSolution
+}
+
+func newAlternateSolutions() *alternateSolutions {
+	return &alternateSolutions{solutions: make(map[types.UID]alternateSolution)}
+}
+
+// record sets the solution of the hint to place the pod with in its next scheduling cycle
+func (a *alternateSolutions) record(uid types.UID, hintName string, solutionIndex int) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.solutions[uid] = alternateSolution{hintName: hintName, solutionIndex: solutionIndex}
+}
+
+// take returns and forgets the solution recorded for the pod, if it was recorded for the given hint
+func (a *alternateSolutions) take(uid types.UID, hintName string) (int, bool) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	alternate, ok := a.solutions[uid]
+	delete(a.solutions, uid)
+	return alternate.solutionIndex, ok && alternate.hintName == hintName
+}
+
+// forget drops the solution recorded for a pod that will not be scheduled again
+func (a *alternateSolutions) forget(uid types.UID) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	delete(a.solutions, uid)
+}
+
+// PostFilter implements the PostFilter extension point. When Filter left the pod without a feasible node
+// because other plugins rejected the selected solution's target nodes, it records the best ranked other
+// solution with a target node that only this plugin rejected and that passes every filter plugin once the
+// solution is used. The pod stays Unschedulable, and its next scheduling cycle places it according to the
+// recorded solution. The plugin is meant to run after DefaultPreemption, so preemption for the selected
+// solution is tried first, and only does anything when Filter excludes nodes, so it only needs to be
+// enabled at the PostFilter extension point together with one of the filter flags
+func (s *MultiObjectiveScheduler) PostFilter(ctx context.Context, state *framework.CycleState, pod *v1.Pod, filteredNodeStatusMap framework.NodeToStatusMap) (*framework.PostFilterResult, *framework.Status) {
+	if !s.filtersNodes() {
+		return nil, framework.NewStatus(framework.Unschedulable)
+	}
+	hs := s.getHintState(ctx, state, pod)
+	if hs.movement == nil {
+		return nil, framework.NewStatus(framework.Unschedulable)
+	}
+
+	rsKey := s.getReplicaSetKey(pod)
+	for _, i := range solutionsByRank(hs.hint) {
+		if i == hs.solutionIndex || !s.solutionApplicable(&hs.hint.Spec.Solutions[i]) {
+			continue
+		}
+		movement := findReplicaSetMovement(&hs.hint.Spec.Solutions[i], rsKey)
+		if nodeName := s.alternateTargetNode(ctx, state, pod, hs.hint, i, movement, filteredNodeStatusMap); nodeName != "" {
+			s.logger.V(3).Info("Target nodes of the selected solution are infeasible - using a lower ranked solution in the next cycle",
+				"pod", klog.KObj(pod), "replicaSet", rsKey, "hint", hs.hint.Name,
+				"selectedSolution", hs.solutionIndex, "solution", i, "node", nodeName)
+			s.alternates.record(pod.UID, hs.hint.Name, i)
+			return nil, framework.NewStatus(framework.Unschedulable,
+				fmt.Sprintf("no feasible target node in the selected scheduling hint solution, solution %d is used in the next scheduling cycle", i))
+		}
+	}
+	return nil, framework.NewStatus(framework.Unschedulable, "no feasible target node in other scheduling hint solutions")
+}
+
+// alternateTargetNode returns the movement's target node with the most headroom among those rejected by
+// this plugin's Filter that pass every filter plugin once the movement's solution is used. A node this
+// plugin rejected was never run through the filter plugins ordered after it, so they are run again here
+func (s *MultiObjectiveScheduler) alternateTargetNode(ctx context.Context, state *framework.CycleState, pod *v1.Pod, hint *deschedulerv1alpha1.SchedulingHint, solutionIndex int, movement *deschedulerv1alpha1.ReplicaSetMovement, filteredNodeStatusMap framework.NodeToStatusMap) string {
+	if movement == nil {
+		return ""
+	}
+
+	// Filter sees the alternate solution in a copy of the cycle state, so the pod's own cycle is unchanged
+	alternateState := state.Clone()
+	alternateState.Write(hintStateKey, &hintState{hint: hint, solutionIndex: solutionIndex, movement: movement})
+
+	bestNode := ""
+	bestSlots, bestTarget := 0, 0
+	for nodeName, targetCount := range movement.TargetDistribution {
+		availableSlots := movement.AvailableSlotsFor(nodeName)
+		if targetCount <= 0 || availableSlots <= 0 {
+			continue
+		}
+		status, ok := filteredNodeStatusMap[nodeName]
+		if !ok || status.Plugin() != Name {
+			continue
+		}
+		if bestNode != "" && !hasMoreHeadroom(nodeName, availableSlots, targetCount, bestNode, bestSlots, bestTarget) {
+			continue
+		}
+		nodeInfo, err := s.handle.SnapshotSharedLister().NodeInfos().Get(nodeName)
+		if err != nil {
+			continue
+		}
+		if status := s.handle.RunFilterPluginsWithNominatedPods(ctx, alternateState, pod, nodeInfo); !status.IsSuccess() {
+			s.logger.V(4).Info("Target node of a lower ranked solution is infeasible",
+				"pod", klog.KObj(pod), "hint", hint.Name, "solution", solutionIndex, "node", nodeName,
+				"plugin", status.Plugin(), "reason", status.Message())
+			continue
+		}
+		bestNode = nodeName
+		bestSlots, bestTarget = availableSlots, targetCount
+	}
+	return bestNode
+}
+
+// solutionsByRank returns the indexes of the hint's solutions ordered by rank
+func solutionsByRank(hint *deschedulerv1alpha1.SchedulingHint) []int {
+	byRank := make([]int, len(hint.Spec.Solutions))
+	for i := range byRank {
+		byRank[i] = i
+	}
+	sort.SliceStable(byRank, func(i, j int) bool {
+		return hint.Spec.Solutions[byRank[i]].Rank < hint.Spec.Solutions[byRank[j]].Rank
+	})
+	return byRank
+}
@@ -0,0 +1,205 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+	tf "k8s.io/kubernetes/pkg/scheduler/testing/framework"
+
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+	deschedulerfake "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/fake"
+)
+
+// rejectNodePlugin is a filter plugin rejecting a single node, standing in for the filter plugins ordered
+// after MultiObjective
+type rejectNodePlugin struct {
+	nodeName string
+}
+
+func (p *rejectNodePlugin) Name() string {
+	return "RejectNode"
+}
+
+func (p *rejectNodePlugin) Filter(_ context.Context, _ *framework.CycleState, _ *v1.Pod, nodeInfo *framework.NodeInfo) *framework.Status {
+	if nodeInfo.Node().Name == p.nodeName {
+		return framework.NewStatus(framework.Unschedulable, "rejected")
+	}
+	return nil
+}
+
+func TestPostFilter(t *testing.T) {
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+		st.MakeNode().Name("node-c").Obj(),
+	}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	solution := func(rank int, targets map[string]int) deschedulerv1alpha1.OptimizationSolution {
+		return deschedulerv1alpha1.OptimizationSolution{
+			Rank: rank,
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					Namespace:          "default",
+					ReplicaSetName:     "web",
+					TargetDistribution: targets,
+					AvailableSlots:     targets,
+				},
+			},
+		}
+	}
+	// Solutions are listed out of rank order to check that the best ranked alternative is recorded
+	solutions := []deschedulerv1alpha1.OptimizationSolution{
+		solution(1, map[string]int{"node-a": 2}),
+		solution(3, map[string]int{"node-c": 2}),
+		solution(2, map[string]int{"node-b": 2}),
+	}
+	rejectedBy := func(plugin string) *framework.Status {
+		return framework.NewStatus(framework.Unschedulable, "rejected").WithPlugin(plugin)
+	}
+
+	tests := []struct {
+		name                 string
+		filterNonTargetNodes bool
+		solutions            []deschedulerv1alpha1.OptimizationSolution
+		statuses             framework.NodeToStatusMap
+		// rejectedNode is rejected by a filter plugin that never ran on it, since MultiObjective rejected it first
+		rejectedNode string
+		// wantTarget is the node passing Filter in the next cycle, the target of the recorded solution if any
+		wantTarget string
+	}{
+		{
+			name:                 "lower ranked target feasible",
+			filterNonTargetNodes: true,
+			solutions:            solutions,
+			statuses: framework.NodeToStatusMap{
+				"node-a": rejectedBy("NodeResourcesFit"),
+				"node-b": rejectedBy(Name),
+				"node-c": rejectedBy(Name),
+			},
+			wantTarget: "node-b",
+		},
+		{
+			name:                 "next ranked target rejected by another plugin",
+			filterNonTargetNodes: true,
+			solutions:            solutions,
+			statuses: framework.NodeToStatusMap{
+				"node-a": rejectedBy("NodeResourcesFit"),
+				"node-b": rejectedBy("TaintToleration"),
+				"node-c": rejectedBy(Name),
+			},
+			wantTarget: "node-c",
+		},
+		{
+			name:                 "next ranked target rejected by a later filter plugin",
+			filterNonTargetNodes: true,
+			solutions:            solutions,
+			statuses: framework.NodeToStatusMap{
+				"node-a": rejectedBy("NodeResourcesFit"),
+				"node-b": rejectedBy(Name),
+				"node-c": rejectedBy(Name),
+			},
+			rejectedNode: "node-b",
+			wantTarget:   "node-c",
+		},
+		{
+			name:                 "no feasible target",
+			filterNonTargetNodes: true,
+			solutions:            solutions,
+			statuses: framework.NodeToStatusMap{
+				"node-a": rejectedBy("NodeResourcesFit"),
+				"node-b": rejectedBy("NodeResourcesFit"),
+				"node-c": rejectedBy("NodeResourcesFit"),
+			},
+			wantTarget: "node-a",
+		},
+		{
+			name:                 "no hint",
+			filterNonTargetNodes: true,
+			statuses: framework.NodeToStatusMap{
+				"node-a": rejectedBy("NodeResourcesFit"),
+				"node-b": rejectedBy("NodeResourcesFit"),
+				"node-c": rejectedBy("NodeResourcesFit"),
+			},
+		},
+		{
+			name:      "filter disabled",
+			solutions: solutions,
+			statuses: framework.NodeToStatusMap{
+				"node-a": rejectedBy("NodeResourcesFit"),
+				"node-b": rejectedBy(Name),
+				"node-c": rejectedBy(Name),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			args := defaultArgs()
+			args.FilterNonTargetNodes = tt.filterNonTargetNodes
+			pod := st.MakePod().Namespace("default").Name("web-0").UID("web-0").OwnerReference("web", rsOwner).Obj()
+			rejectNode := func(_ context.Context, _ runtime.Object, _ framework.Handle) (framework.Plugin, error) {
+				return &rejectNodePlugin{nodeName: tt.rejectedNode}, nil
+			}
+			fr, informerFactory := newTestFrameworkWithPlugins(ctx, t,
+				[]tf.RegisterPluginFunc{tf.RegisterFilterPlugin("RejectNode", rejectNode)},
+				nodes, makeReplicaSet("default", "web", 2))
+			p, err := newWithClient(ctx, args, fr, deschedulerfake.NewSimpleClientset())
+			if err != nil {
+				t.Fatalf("newWithClient() unexpected error: %v", err)
+			}
+			informerFactory.Start(ctx.Done())
+			informerFactory.WaitForCacheSync(ctx.Done())
+			s := p.(*MultiObjectiveScheduler)
+			if len(tt.solutions) > 0 {
+				createHint(ctx, t, s, tt.solutions...)
+			}
+
+			state := framework.NewCycleState()
+			s.PreFilter(ctx, state, pod)
+			result, status := s.PostFilter(ctx, state, pod, tt.statuses)
+			if status.Code() != framework.Unschedulable {
+				t.Errorf("PostFilter() status = %v, want Unschedulable", status)
+			}
+			if result != nil {
+				t.Errorf("PostFilter() nominated %v, want no nomination", result.NominatingInfo)
+			}
+			if tt.wantTarget == "" {
+				return
+			}
+
+			// The next cycle uses the recorded solution, so only its target node passes Filter
+			state = framework.NewCycleState()
+			s.PreFilter(ctx, state, pod)
+			for _, node := range nodes {
+				status := s.Filter(ctx, state, pod, makeNodeInfo(node))
+				if got, want := status.IsSuccess(), node.Name == tt.wantTarget; got != want {
+					t.Errorf("Filter(%s) in the next cycle: schedulable = %v, want %v", node.Name, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+)
+
+// PreferenceAnnotation lets a pod declare which objectives its placement should favor, either as a
+// single objective name (e.g. "cost") or as comma separated weights (e.g. "cost=0.7,balance=0.3").
+// Any objective a solution reports a value for can be named, including its NamedObjectives
+const PreferenceAnnotation = "multiobjective.x-k8s.io/prefer"
+
+// WeightAnnotationPrefix lets a pod weigh one objective per annotation, e.g. "multiobjective.x-k8s.io/weight-cost: 0.7".
+// Weight annotations are ignored on pods that set PreferenceAnnotation
+const WeightAnnotationPrefix = "multiobjective.x-k8s.io/weight-"
+
+// objectiveWeights weighs the objectives of a solution by name, all of which are minimized
+type objectiveWeights map[string]float64
+
+// parsePreference parses the value of the preference annotation into objective weights
+func parsePreference(value string) (objectiveWeights, error) {
+	weights := objectiveWeights{}
+	total := 0.0
+	for _, entry := range strings.Split(value, ",") {
+		name, weightStr, hasWeight := strings.Cut(strings.TrimSpace(entry), "=")
+		weight := 1.0
+		if hasWeight {
+			var err error
+			weight, err = strconv.ParseFloat(strings.TrimSpace(weightStr), 64)
+			if err != nil || weight < 0 {
+				return nil, fmt.Errorf("invalid weight %q for objective %q", weightStr, name)
+			}
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			return nil, fmt.Errorf("missing objective name in preference %q", value)
+		}
+		weights[name] = weight
+		total += weight
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("preference %q has no positive weight", value)
+	}
+	return weights, nil
+}
+
+// parseWeightAnnotations parses the pod's weight annotations into objective weights, or returns nil if
+// the pod has none
+func parseWeightAnnotations(annotations map[string]string) (objectiveWeights, error) {
+	var weights objectiveWeights
+	total := 0.0
+	for key, value := range annotations {
+		name, ok := strings.CutPrefix(key, WeightAnnotationPrefix)
+		if !ok {
+			continue
+		}
+		name = strings.ToLower(name)
+		if name == "" {
+			return nil, fmt.Errorf("missing objective name in annotation %q", key)
+		}
+		weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("invalid weight %q in annotation %q", value, key)
+		}
+		if weights == nil {
+			weights = objectiveWeights{}
+		}
+		weights[name] = weight
+		total += weight
+	}
+
+	if weights != nil && total == 0 {
+		return nil, fmt.Errorf("weight annotations have no positive weight")
+	}
+	return weights, nil
+}
+
+// podObjectiveWeights returns the objective weights declared by the pod's annotations, or nil if it
+// declares none
+func podObjectiveWeights(pod *v1.Pod) (objectiveWeights, error) {
+	if value, ok := pod.Annotations[PreferenceAnnotation]; ok {
+		return parsePreference(value)
+	}
+	return parseWeightAnnotations(pod.Annotations)
+}
+
+// hintWeights returns the objective weights the descheduler recorded in the hint, if any
+func hintWeights(hint *deschedulerv1alpha1.SchedulingHint) (objectiveWeights, bool) {
+	w := hint.Spec.ObjectiveWeights
+	if w == nil || w.Cost+w.Disruption+w.Balance <= 0 {
+		return nil, false
+	}
+	return objectiveWeights{
+		deschedulerv1alpha1.ObjectiveCost:       w.Cost,
+		deschedulerv1alpha1.ObjectiveDisruption: w.Disruption,
+		deschedulerv1alpha1.ObjectiveBalance:    w.Balance,
+	}, true
+}
+
+// selectSolutionIndex returns the index of the hint solution to place the pod with. Pods declaring
+// a preference or objective weights get the solution with the lowest weighted score for them; all other
+// pods are ranked by the weights recorded in the hint, falling back to the top solution when the hint has none.
+// Only solutions within MaxWeightedScore are candidates
+func (s *MultiObjectiveScheduler) selectSolutionIndex(pod *v1.Pod, hint *deschedulerv1alpha1.SchedulingHint) int {
+	candidates := s.applicableSolutions(hint)
+	if len(candidates) == 0 {
+		return 0
+	}
+	if len(candidates) == 1 {
+		return candidates[0]
+	}
+
+	weights, err := podObjectiveWeights(pod)
+	if err != nil {
+		s.logger.V(3).Info("Ignoring invalid objective preference",
+			"pod", klog.KObj(pod), "error", err.Error())
+		weights = nil
+	}
+	source := "pod"
+	if weights == nil {
+		source = "hint"
+		var ok bool
+		if weights, ok = hintWeights(hint); !ok {
+			return candidates[0]
+		}
+	}
+
+	// Objectives are normalized across the candidates, and ties keep the earlier, better ranked solution
+	scores := hint.ComputeNamedWeightedScores(weights, candidates)
+	best, bestScore := candidates[0], scores[0]
+	for j, i := range candidates[1:] {
+		if score := scores[j+1]; score < bestScore {
+			best, bestScore = i, score
+		}
+	}
+
+	s.logger.V(4).Info("Selected solution by objective weights",
+		"pod", klog.KObj(pod), "weightsFrom", source, "solution", best, "weightedScore", bestScore,
+		"objectives", hint.Spec.Solutions[best].AllObjectives())
+	return best
+}
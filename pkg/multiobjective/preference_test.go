@@ -0,0 +1,376 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+)
+
+func TestParsePreference(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    objectiveWeights
+		wantErr bool
+	}{
+		{
+			name:  "single objective",
+			value: "cost",
+			want:  objectiveWeights{"cost": 1},
+		},
+		{
+			name:  "weighted objectives",
+			value: "cost=0.7, Balance=0.3",
+			want:  objectiveWeights{"cost": 0.7, "balance": 0.3},
+		},
+		{
+			name:  "named objective",
+			value: "power=0.5,cost=0.5",
+			want:  objectiveWeights{"power": 0.5, "cost": 0.5},
+		},
+		{
+			name:    "missing objective name",
+			value:   "cost=0.5,=0.5",
+			wantErr: true,
+		},
+		{
+			name:    "invalid weight",
+			value:   "cost=high",
+			wantErr: true,
+		},
+		{
+			name:    "negative weight",
+			value:   "cost=-1",
+			wantErr: true,
+		},
+		{
+			name:    "all weights zero",
+			value:   "cost=0,balance=0",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePreference(tt.value)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parsePreference(%q) expected error, got none", tt.value)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parsePreference(%q) unexpected error: %v", tt.value, err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parsePreference(%q) unexpected weights (-want, +got):\n%s", tt.value, diff)
+			}
+		})
+	}
+}
+
+func TestParseWeightAnnotations(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        objectiveWeights
+		wantErr     bool
+	}{
+		{
+			name:        "no weight annotations",
+			annotations: map[string]string{PreferenceAnnotation + "-other": "cost"},
+		},
+		{
+			name: "weighted objectives",
+			annotations: map[string]string{
+				WeightAnnotationPrefix + "cost":    "0.7",
+				WeightAnnotationPrefix + "Balance": " 0.3",
+				WeightAnnotationPrefix + "power":   "0",
+			},
+			want: objectiveWeights{"cost": 0.7, "balance": 0.3, "power": 0},
+		},
+		{
+			name:        "missing objective name",
+			annotations: map[string]string{WeightAnnotationPrefix: "1"},
+			wantErr:     true,
+		},
+		{
+			name:        "invalid weight",
+			annotations: map[string]string{WeightAnnotationPrefix + "cost": "high"},
+			wantErr:     true,
+		},
+		{
+			name:        "negative weight",
+			annotations: map[string]string{WeightAnnotationPrefix + "cost": "-1"},
+			wantErr:     true,
+		},
+		{
+			name:        "all weights zero",
+			annotations: map[string]string{WeightAnnotationPrefix + "cost": "0", WeightAnnotationPrefix + "balance": "0"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWeightAnnotations(tt.annotations)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseWeightAnnotations() expected error, got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseWeightAnnotations() unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("parseWeightAnnotations() unexpected weights (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+// preferenceSolutions returns three solutions that each excel at one objective, all moving
+// default/web to a different node
+func preferenceSolutions() []deschedulerv1alpha1.OptimizationSolution {
+	solution := func(rank int, node string, objectives deschedulerv1alpha1.ObjectiveValues, power float64) deschedulerv1alpha1.OptimizationSolution {
+		return deschedulerv1alpha1.OptimizationSolution{
+			Rank:            rank,
+			Objectives:      objectives,
+			NamedObjectives: map[string]float64{"power": power},
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					Namespace:          "default",
+					ReplicaSetName:     "web",
+					TargetDistribution: map[string]int{node: 1},
+					AvailableSlots:     map[string]int{node: 1},
+				},
+			},
+		}
+	}
+	return []deschedulerv1alpha1.OptimizationSolution{
+		solution(1, "node-a", deschedulerv1alpha1.ObjectiveValues{Cost: 0.5, Disruption: 0.5, Balance: 0.1}, 0.9),
+		solution(1, "node-b", deschedulerv1alpha1.ObjectiveValues{Cost: 0.1, Disruption: 0.6, Balance: 0.6}, 0.5),
+		solution(1, "node-c", deschedulerv1alpha1.ObjectiveValues{Cost: 0.6, Disruption: 0.1, Balance: 0.5}, 0.2),
+	}
+}
+
+func TestSelectSolutionIndex(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		hintWeights *deschedulerv1alpha1.ObjectiveWeights
+		want        int
+	}{
+		{
+			name: "no preference uses top solution",
+			want: 0,
+		},
+		{
+			name:        "prefer cost",
+			annotations: map[string]string{PreferenceAnnotation: "cost"},
+			want:        1,
+		},
+		{
+			name:        "prefer disruption",
+			annotations: map[string]string{PreferenceAnnotation: "disruption"},
+			want:        2,
+		},
+		{
+			name:        "prefer balance",
+			annotations: map[string]string{PreferenceAnnotation: "balance"},
+			want:        0,
+		},
+		{
+			name:        "weighted preference",
+			annotations: map[string]string{PreferenceAnnotation: "cost=0.2,disruption=0.8"},
+			want:        2,
+		},
+		{
+			name:        "prefer named objective",
+			annotations: map[string]string{PreferenceAnnotation: "power"},
+			want:        2,
+		},
+		{
+			name:        "weighted preference with named objective",
+			annotations: map[string]string{PreferenceAnnotation: "power=1,cost=1"},
+			want:        1,
+		},
+		{
+			name:        "objective missing from all solutions uses top solution",
+			annotations: map[string]string{PreferenceAnnotation: "latency"},
+			want:        0,
+		},
+		{
+			name:        "invalid preference uses top solution",
+			annotations: map[string]string{PreferenceAnnotation: "cost=high"},
+			want:        0,
+		},
+		{
+			name:        "weight annotation for cost",
+			annotations: map[string]string{WeightAnnotationPrefix + "cost": "1"},
+			want:        1,
+		},
+		{
+			name:        "weight annotation for power",
+			annotations: map[string]string{WeightAnnotationPrefix + "power": "1"},
+			want:        2,
+		},
+		{
+			name:        "weight annotation for balance",
+			annotations: map[string]string{WeightAnnotationPrefix + "balance": "1"},
+			want:        0,
+		},
+		{
+			name:        "weight annotations for several objectives",
+			annotations: map[string]string{WeightAnnotationPrefix + "power": "1", WeightAnnotationPrefix + "cost": "1"},
+			want:        1,
+		},
+		{
+			name:        "preference overrides weight annotations",
+			annotations: map[string]string{PreferenceAnnotation: "disruption", WeightAnnotationPrefix + "cost": "1"},
+			want:        2,
+		},
+		{
+			name:        "invalid weight annotation uses top solution",
+			annotations: map[string]string{WeightAnnotationPrefix + "cost": "high"},
+			want:        0,
+		},
+		{
+			name:        "no preference uses hint weights",
+			hintWeights: &deschedulerv1alpha1.ObjectiveWeights{Cost: 0.9, Balance: 0.1},
+			want:        1,
+		},
+		{
+			name:        "pod preference overrides hint weights",
+			annotations: map[string]string{PreferenceAnnotation: "disruption"},
+			hintWeights: &deschedulerv1alpha1.ObjectiveWeights{Cost: 0.9, Balance: 0.1},
+			want:        2,
+		},
+		{
+			name:        "invalid preference uses hint weights",
+			annotations: map[string]string{PreferenceAnnotation: "cost=high"},
+			hintWeights: &deschedulerv1alpha1.ObjectiveWeights{Cost: 1},
+			want:        1,
+		},
+		{
+			name:        "all zero hint weights use top solution",
+			hintWeights: &deschedulerv1alpha1.ObjectiveWeights{},
+			want:        0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hint := &deschedulerv1alpha1.SchedulingHint{
+				ObjectMeta: metav1.ObjectMeta{Name: "multiobjective-hints-abc"},
+				Spec: deschedulerv1alpha1.SchedulingHintSpec{
+					Solutions:        preferenceSolutions(),
+					ObjectiveWeights: tt.hintWeights,
+				},
+			}
+			pod := st.MakePod().Namespace("default").Name("web-0").Annotations(tt.annotations).Obj()
+			s := &MultiObjectiveScheduler{logger: klog.Background(), args: defaultArgs()}
+			if got := s.selectSolutionIndex(pod, hint); got != tt.want {
+				t.Errorf("selectSolutionIndex() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectSolutionIndexNormalizesObjectives(t *testing.T) {
+	// Power is in watts and cost in [0,1], so unnormalized power would outweigh cost at equal weights
+	solution := func(rank int, cost, power float64) deschedulerv1alpha1.OptimizationSolution {
+		return deschedulerv1alpha1.OptimizationSolution{
+			Rank:            rank,
+			Objectives:      deschedulerv1alpha1.ObjectiveValues{Cost: cost},
+			NamedObjectives: map[string]float64{"power": power},
+		}
+	}
+	hint := &deschedulerv1alpha1.SchedulingHint{
+		ObjectMeta: metav1.ObjectMeta{Name: "multiobjective-hints-abc"},
+		Spec: deschedulerv1alpha1.SchedulingHintSpec{
+			Solutions: []deschedulerv1alpha1.OptimizationSolution{
+				solution(1, 0.9, 500),
+				solution(2, 0.1, 520),
+				solution(3, 0.5, 900),
+			},
+		},
+	}
+	pod := st.MakePod().Namespace("default").Name("web-0").
+		Annotations(map[string]string{PreferenceAnnotation: "cost=1,power=1"}).Obj()
+	s := &MultiObjectiveScheduler{logger: klog.Background(), args: defaultArgs()}
+	if got := s.selectSolutionIndex(pod, hint); got != 1 {
+		t.Errorf("selectSolutionIndex() = %d, want %d", got, 1)
+	}
+}
+
+func TestPreferenceSelectsSolutionForPlacement(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{
+		st.MakeNode().Name("node-a").Obj(),
+		st.MakeNode().Name("node-b").Obj(),
+		st.MakeNode().Name("node-c").Obj(),
+	}
+	nodeInfos := make([]*framework.NodeInfo, len(nodes))
+	for i, node := range nodes {
+		nodeInfos[i] = makeNodeInfo(node)
+	}
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 3))
+	hint := createHint(ctx, t, s, preferenceSolutions()...)
+
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).
+		Annotations(map[string]string{PreferenceAnnotation: "cost"}).Obj()
+
+	state := framework.NewCycleState()
+	if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+		t.Fatalf("PreScore() unexpected status: %v", status)
+	}
+	if score, _ := s.Score(ctx, state, pod, "node-b"); score != MaxNodeScore {
+		t.Errorf("Score(node-b) = %d, want %d for the cost-optimal solution's target", score, MaxNodeScore)
+	}
+	if status := s.Reserve(ctx, state, pod, "node-b"); !status.IsSuccess() {
+		t.Fatalf("Reserve() unexpected status: %v", status)
+	}
+
+	// The slot is consumed in the selected solution, not the top one
+	got, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("failed to get scheduling hint: %v", err)
+	}
+	if slots := got.Spec.Solutions[1].ReplicaSetMovements[0].AvailableSlots["node-b"]; slots != 0 {
+		t.Errorf("selected solution slots on node-b = %d, want 0", slots)
+	}
+	if slots := got.Spec.Solutions[0].ReplicaSetMovements[0].AvailableSlots["node-a"]; slots != 1 {
+		t.Errorf("top solution slots on node-a = %d, want 1", slots)
+	}
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+	"sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned"
+)
+
+// slotKey identifies the slots of a ReplicaSet on a node in a hint solution
+type slotKey struct {
+	solutionIndex int
+	rsKey         string
+	nodeName      string
+}
+
+// activate runs before the plugin's first scheduling cycle. kube-scheduler only schedules on the elected
+// leader, so slots are reconciled once this replica leads, and before any pod it schedules holds a slot that
+// is consumed but not yet marked on the pod. A dry run never touches slots
+func (s *MultiObjectiveScheduler) activate(ctx context.Context) {
+	s.activateOnce.Do(func() {
+		if !s.args.DryRun {
+			s.reconcileSlots(ctx, s.client)
+		}
+		s.active.Store(true)
+	})
+}
+
+// reconcileSlots corrects the slots of all active hints created with the configured name prefix from the pods
+// marked with ConsumedSlotAnnotation. A scheduler that stopped between consuming a slot and marking the pod, or
+// between a pod's deletion and returning its slot, leaves ScheduledCount out of step with the pods placed by the hint
+func (s *MultiObjectiveScheduler) reconcileSlots(ctx context.Context, client versioned.Interface) {
+	hints, err := client.DeschedulerV1alpha1().SchedulingHints().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		s.logger.V(3).Info("Cannot list scheduling hints to reconcile slots", "error", err.Error())
+		return
+	}
+	pods, err := s.handle.SharedInformerFactory().Core().V1().Pods().Lister().List(labels.Everything())
+	if err != nil {
+		s.logger.V(3).Info("Cannot list pods to reconcile slots", "error", err.Error())
+		return
+	}
+
+	// Count the slots held by pods per hint. The scheduler's pod informer does not hold terminated pods, so
+	// their slots are returned here just as the delete handler returns them once a pod terminates
+	consumed := make(map[string]map[slotKey]int)
+	for _, pod := range pods {
+		if !hasConsumedSlot(pod) {
+			continue
+		}
+		slot, err := parseConsumedSlot(pod.Annotations[ConsumedSlotAnnotation])
+		if err != nil {
+			continue
+		}
+		if consumed[slot.hintName] == nil {
+			consumed[slot.hintName] = make(map[slotKey]int)
+		}
+		consumed[slot.hintName][slotKey{solutionIndex: slot.solutionIndex, rsKey: s.getReplicaSetKey(pod), nodeName: slot.nodeName}]++
+	}
+
+	for i := range hints.Items {
+		hint := &hints.Items[i]
+		if !strings.HasPrefix(hint.Name, s.args.HintNamePrefix) || hintUnusableReason(hint, s.clock.Now()) != "" {
+			continue
+		}
+		s.reconcileHintSlots(ctx, client, hint.Name, consumed[hint.Name])
+	}
+}
+
+// reconcileHintSlots sets the hint's ScheduledCount entries to the slots held by pods, moving the difference
+// into or out of AvailableSlots. Only the corrected entries are patched, with the fetched resourceVersion as a
+// precondition so a concurrent slot update makes the patch fail with a conflict and retry on a fresh fetch
+func (s *MultiObjectiveScheduler) reconcileHintSlots(ctx context.Context, client versioned.Interface, hintName string, consumed map[slotKey]int) {
+	backoff := wait.Backoff{
+		Duration: slotUpdateInitialBackoff,
+		Factor:   slotUpdateBackoffFactor,
+		Jitter:   slotUpdateBackoffJitter,
+		Steps:    int(s.args.SlotUpdateMaxRetries),
+	}
+	for attempt := 1; attempt <= int(s.args.SlotUpdateMaxRetries); attempt++ {
+		if attempt > 1 {
+			if ctx.Err() != nil {
+				return
+			}
+			s.clock.Sleep(backoff.Step())
+		}
+
+		hint, err := client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hintName, metav1.GetOptions{})
+		if err != nil {
+			s.logger.V(3).Info("Cannot fetch hint to reconcile slots", "hint", hintName, "attempt", attempt, "error", err.Error())
+			continue
+		}
+		operations, corrected := correctSlots(hint, consumed)
+		if corrected == 0 {
+			return
+		}
+		patch, err := preconditionedPatch(hint.ResourceVersion, operations)
+		if err != nil {
+			s.logger.Error(err, "Failed to build slot reconciliation patch", "hint", hintName)
+			return
+		}
+
+		updatedHint, err := client.DeschedulerV1alpha1().SchedulingHints().Patch(ctx, hintName, types.JSONPatchType, patch, metav1.PatchOptions{})
+		if apierrors.IsConflict(err) {
+			s.logger.V(3).Info("Conflicting hint update during slot reconciliation - retrying", "hint", hintName, "attempt", attempt)
+			continue
+		}
+		if err != nil {
+			s.logger.V(3).Info("Failed to patch hint to reconcile slots", "hint", hintName, "error", err.Error())
+			return
+		}
+		s.logger.V(2).Info("Reconciled scheduling hint slots with the pods holding them", "hint", hintName, "corrected", corrected)
+		s.updateRemainingSlots(ctx, client, updatedHint)
+		return
+	}
+}
+
+// correctSlots updates the hint's slots in place to match the slots held by pods. It returns the JSON patch
+// operations making the same corrections and the number of corrected nodes. AvailableSlots never drops below zero
+func correctSlots(hint *deschedulerv1alpha1.SchedulingHint, consumed map[slotKey]int) ([]jsonPatchOperation, int) {
+	var operations []jsonPatchOperation
+	corrected := 0
+	for i := range hint.Spec.Solutions {
+		for j := range hint.Spec.Solutions[i].ReplicaSetMovements {
+			movement := &hint.Spec.Solutions[i].ReplicaSetMovements[j]
+			rsKey := fmt.Sprintf("%s/%s", movement.Namespace, movement.ReplicaSetName)
+
+			nodeNames := make(map[string]bool)
+			for nodeName := range movement.ScheduledCount {
+				nodeNames[nodeName] = true
+			}
+			for key := range consumed {
+				if key.solutionIndex == i && key.rsKey == rsKey {
+					nodeNames[key.nodeName] = true
+				}
+			}
+
+			for nodeName := range nodeNames {
+				scheduled := movement.ScheduledCountFor(nodeName)
+				actual := consumed[slotKey{solutionIndex: i, rsKey: rsKey, nodeName: nodeName}]
+				if scheduled == actual {
+					continue
+				}
+				available := max(movement.AvailableSlotsFor(nodeName)+scheduled-actual, 0)
+				operations = append(operations, slotOperations(i, j, movement, nodeName, available, actual)...)
+				movement.SetSlots(nodeName, available, actual)
+				corrected++
+			}
+		}
+	}
+	return operations, corrected
+}
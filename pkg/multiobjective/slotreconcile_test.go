@@ -0,0 +1,166 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+
+	cfgv1 "sigs.k8s.io/scheduler-plugins/apis/config/v1"
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+	deschedulerfake "sigs.k8s.io/scheduler-plugins/pkg/generated/clientset/versioned/fake"
+)
+
+func TestReconcileSlots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj(), st.MakeNode().Name("node-b").Obj()}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	holding := func(name, slot string) *v1.Pod {
+		return st.MakePod().Namespace("default").Name(name).OwnerReference("web", rsOwner).
+			Annotations(map[string]string{ConsumedSlotAnnotation: slot}).Obj()
+	}
+	activeName := cfgv1.DefaultMultiObjectiveHintNamePrefix + "active"
+	pods := []*v1.Pod{
+		holding("web-0", activeName+"/0/node-a"),
+		holding("web-1", activeName+"/0/node-b"),
+		holding("web-2", activeName+"/1/node-b"),
+		st.MakePod().Namespace("default").Name("web-3").OwnerReference("web", rsOwner).Obj(),
+	}
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 4), pods[0], pods[1], pods[2], pods[3])
+
+	// slots builds a solution whose recorded slots are (available, scheduled) per node
+	slots := func(recorded map[string][2]int) deschedulerv1alpha1.OptimizationSolution {
+		movement := deschedulerv1alpha1.ReplicaSetMovement{
+			Namespace:          "default",
+			ReplicaSetName:     "web",
+			TargetDistribution: map[string]int{"node-a": 2, "node-b": 2},
+		}
+		for nodeName, value := range recorded {
+			movement.SetSlots(nodeName, value[0], value[1])
+		}
+		return deschedulerv1alpha1.OptimizationSolution{Rank: 1, ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{movement}}
+	}
+	// A slot was consumed for a pod that never got marked on node-a, and a marked pod's slot was returned on node-b
+	drifted := []deschedulerv1alpha1.OptimizationSolution{
+		slots(map[string][2]int{"node-a": {0, 2}, "node-b": {2, 0}}),
+		slots(map[string][2]int{"node-a": {2, 0}, "node-b": {1, 1}}),
+	}
+	hints := []*deschedulerv1alpha1.SchedulingHint{
+		{ObjectMeta: metav1.ObjectMeta{Name: activeName}, Spec: deschedulerv1alpha1.SchedulingHintSpec{Solutions: drifted}},
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: cfgv1.DefaultMultiObjectiveHintNamePrefix + "expired"},
+			Spec:       deschedulerv1alpha1.SchedulingHintSpec{Solutions: drifted},
+			Status:     deschedulerv1alpha1.SchedulingHintStatus{Phase: deschedulerv1alpha1.SchedulingHintPhaseExpired},
+		},
+		{ObjectMeta: metav1.ObjectMeta{Name: "other-hints-abc"}, Spec: deschedulerv1alpha1.SchedulingHintSpec{Solutions: drifted}},
+	}
+	for _, hint := range hints {
+		if _, err := s.client.DeschedulerV1alpha1().SchedulingHints().Create(ctx, hint, metav1.CreateOptions{}); err != nil {
+			t.Fatalf("failed to create scheduling hint: %v", err)
+		}
+	}
+
+	fakeClient := s.client.(*deschedulerfake.Clientset)
+	fakeClient.ClearActions()
+	s.reconcileSlots(ctx, s.client)
+
+	// Slots are corrected with targeted patches rather than by overwriting the whole hint
+	for _, action := range fakeClient.Actions() {
+		if action.GetVerb() == "update" {
+			t.Errorf("unexpected update of %s during slot reconciliation", action.GetResource().Resource)
+		}
+	}
+
+	want := map[string][]deschedulerv1alpha1.OptimizationSolution{
+		activeName: {
+			slots(map[string][2]int{"node-a": {1, 1}, "node-b": {1, 1}}),
+			slots(map[string][2]int{"node-a": {2, 0}, "node-b": {1, 1}}),
+		},
+		// Hints that are not in use are left untouched
+		cfgv1.DefaultMultiObjectiveHintNamePrefix + "expired": drifted,
+		"other-hints-abc": drifted,
+	}
+	for name, wantSolutions := range want {
+		got, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get scheduling hint: %v", err)
+		}
+		if diff := cmp.Diff(wantSolutions, got.Spec.Solutions); diff != "" {
+			t.Errorf("unexpected solutions of hint %s (-want, +got):\n%s", name, diff)
+		}
+	}
+}
+
+func TestReconcileSlotsBeforeFirstCycle(t *testing.T) {
+	for _, dryRun := range []bool{false, true} {
+		t.Run(fmt.Sprintf("dryRun=%v", dryRun), func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj()}
+			rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+			pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", rsOwner).Obj()
+			args := defaultArgs()
+			args.DryRun = dryRun
+			s := newTestScheduler(ctx, t, args, nodes, makeReplicaSet("default", "web", 2))
+			// A slot is recorded as consumed although no pod holds it
+			hint := createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+				Rank: 1,
+				ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+					{
+						Namespace:          "default",
+						ReplicaSetName:     "web",
+						TargetDistribution: map[string]int{"node-a": 2},
+						AvailableSlots:     map[string]int{"node-a": 1},
+						ScheduledCount:     map[string]int{"node-a": 1},
+					},
+				},
+			})
+			availableSlots := func() int {
+				t.Helper()
+				got, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("failed to get scheduling hint: %v", err)
+				}
+				return got.Spec.Solutions[0].ReplicaSetMovements[0].AvailableSlotsFor("node-a")
+			}
+			if got := availableSlots(); got != 1 {
+				t.Fatalf("available slots before the first cycle = %d, want 1", got)
+			}
+
+			s.PreFilter(ctx, framework.NewCycleState(), pod)
+			want := 2
+			if dryRun {
+				want = 1
+			}
+			if got := availableSlots(); got != want {
+				t.Errorf("available slots after the first cycle = %d, want %d", got, want)
+			}
+		})
+	}
+}
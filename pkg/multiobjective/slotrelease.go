@@ -0,0 +1,225 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+)
+
+// ConsumedSlotAnnotation marks a pod that consumed a scheduling hint slot, as
+// <hint name>/<solution index>/<node name>. The slot is returned to the hint when the pod is deleted
+const ConsumedSlotAnnotation = "multiobjective.x-k8s.io/consumed-slot"
+
+// Placement annotations record which scheduling hint placed a pod, for post-hoc analysis
+const (
+	HintNameAnnotation     = "multiobjective.x-k8s.io/hint-name"
+	SolutionRankAnnotation = "multiobjective.x-k8s.io/solution-rank"
+	TargetNodeAnnotation   = "multiobjective.x-k8s.io/target-node"
+)
+
+// consumedSlot identifies the hint slot consumed by a pod
+type consumedSlot struct {
+	hintName      string
+	solutionIndex int
+	nodeName      string
+}
+
+// slotRelease is a slot of a deleted pod queued to be returned to its hint
+type slotRelease struct {
+	pod   klog.ObjectRef
+	uid   types.UID
+	rsKey string
+	slot  consumedSlot
+}
+
+func (c consumedSlot) String() string {
+	return fmt.Sprintf("%s/%d/%s", c.hintName, c.solutionIndex, c.nodeName)
+}
+
+// parseConsumedSlot parses the value of ConsumedSlotAnnotation
+func parseConsumedSlot(value string) (consumedSlot, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[2] == "" {
+		return consumedSlot{}, fmt.Errorf("invalid consumed slot %q", value)
+	}
+	solutionIndex, err := strconv.Atoi(parts[1])
+	if err != nil || solutionIndex < 0 {
+		return consumedSlot{}, fmt.Errorf("invalid solution index in consumed slot %q", value)
+	}
+	return consumedSlot{hintName: parts[0], solutionIndex: solutionIndex, nodeName: parts[2]}, nil
+}
+
+// PreBind implements the PreBind extension point. It marks pods placed on a hint's target node with the
+// hint and solution that placed them, and with the slot consumed in Reserve so the slot can be returned
+// once the pod is deleted. Pods placed by default scoring are left untouched
+func (s *MultiObjectiveScheduler) PreBind(ctx context.Context, state *framework.CycleState, pod *v1.Pod, nodeName string) *framework.Status {
+	cycleState := readCycleState(state)
+	if cycleState == nil || !cycleState.HasHint || nodeName != cycleState.TargetNode {
+		return nil
+	}
+
+	annotations := placementAnnotations(cycleState)
+	var slot consumedSlot
+	if cycleState.SlotConsumed {
+		slot = consumedSlot{hintName: cycleState.Hint.Name, solutionIndex: cycleState.SolutionIndex, nodeName: nodeName}
+		annotations[ConsumedSlotAnnotation] = slot.String()
+	}
+	if err := s.patchPodAnnotations(ctx, pod, annotations); err != nil {
+		// The slot stays consumed for the pod's lifetime, which only affects hint accuracy
+		s.logger.V(3).Info("Failed to mark pod with scheduling hint placement",
+			"pod", klog.KObj(pod), "hint", cycleState.Hint.Name, "node", nodeName, "error", err.Error())
+		return nil
+	}
+	cycleState.PlacementAnnotated = true
+	cycleState.SlotAnnotated = cycleState.SlotConsumed
+	return nil
+}
+
+// placementAnnotations returns the annotations recording the hint, solution rank and target node
+// that placed the pod
+func placementAnnotations(cycleState *MultiObjectiveState) map[string]interface{} {
+	annotations := map[string]interface{}{
+		HintNameAnnotation:   cycleState.Hint.Name,
+		TargetNodeAnnotation: cycleState.TargetNode,
+	}
+	if solutions := cycleState.Hint.Spec.Solutions; cycleState.SolutionIndex < len(solutions) {
+		annotations[SolutionRankAnnotation] = strconv.Itoa(solutions[cycleState.SolutionIndex].Rank)
+	}
+	return annotations
+}
+
+// clearPlacementAnnotations removes the annotations set by placementAnnotations from the pod
+func (s *MultiObjectiveScheduler) clearPlacementAnnotations(ctx context.Context, pod *v1.Pod) error {
+	return s.patchPodAnnotations(ctx, pod, map[string]interface{}{
+		HintNameAnnotation:     nil,
+		SolutionRankAnnotation: nil,
+		TargetNodeAnnotation:   nil,
+	})
+}
+
+// patchPodAnnotation sets ConsumedSlotAnnotation on the pod, or removes it if value is empty
+func (s *MultiObjectiveScheduler) patchPodAnnotation(ctx context.Context, pod *v1.Pod, value string) error {
+	var annotation interface{}
+	if value != "" {
+		annotation = value
+	}
+	return s.patchPodAnnotations(ctx, pod, map[string]interface{}{ConsumedSlotAnnotation: annotation})
+}
+
+// patchPodAnnotations merges annotations into the pod's annotations, removing those with a nil value
+func (s *MultiObjectiveScheduler) patchPodAnnotations(ctx context.Context, pod *v1.Pod, annotations map[string]interface{}) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = s.handle.ClientSet().CoreV1().Pods(pod.Namespace).Patch(ctx, pod.Name, types.MergePatchType, patch, metav1.PatchOptions{})
+	return err
+}
+
+// addPodDeleteHandler returns hint slots to the hint when pods that consumed them are deleted. It does
+// not filter on the annotation, since a filtering handler would treat Unreserve removing the
+// annotation as a deletion and release the slot twice. Slots are only released by the plugin of the
+// profile that scheduled the pod, and only once it is scheduling, so that other profiles and replicas
+// waiting for leadership do not release the same slot again
+func (s *MultiObjectiveScheduler) addPodDeleteHandler() {
+	podInformer := s.handle.SharedInformerFactory().Core().V1().Pods().Informer()
+	podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: s.deletePod,
+	})
+}
+
+func hasConsumedSlot(pod *v1.Pod) bool {
+	_, ok := pod.Annotations[ConsumedSlotAnnotation]
+	return ok
+}
+
+// deletePod forgets the solution PostFilter recorded for a deleted pod and queues the slot it consumed for
+// release. The annotation is only set once the slot was consumed and is removed again if Unreserve already
+// released it, so a slot is released once
+func (s *MultiObjectiveScheduler) deletePod(obj interface{}) {
+	var pod *v1.Pod
+	switch t := obj.(type) {
+	case *v1.Pod:
+		pod = t
+	case cache.DeletedFinalStateUnknown:
+		var ok bool
+		pod, ok = t.Obj.(*v1.Pod)
+		if !ok {
+			return
+		}
+	default:
+		return
+	}
+	if s.schedulerName != "" && pod.Spec.SchedulerName != s.schedulerName {
+		return
+	}
+	s.alternates.forget(pod.UID)
+	if !s.active.Load() || !hasConsumedSlot(pod) {
+		return
+	}
+
+	slot, err := parseConsumedSlot(pod.Annotations[ConsumedSlotAnnotation])
+	if err != nil {
+		s.logger.V(3).Info("Ignoring deleted pod with invalid consumed slot",
+			"pod", klog.KObj(pod), "error", err.Error())
+		return
+	}
+
+	s.slotReleases.Add(slotRelease{pod: klog.KObj(pod), uid: pod.UID, rsKey: s.getReplicaSetKey(pod), slot: slot})
+}
+
+// runSlotReleaseWorker returns the slots queued by deletePod to their hints until the scheduler shuts down
+func (s *MultiObjectiveScheduler) runSlotReleaseWorker(ctx context.Context) {
+	go func() {
+		<-ctx.Done()
+		s.slotReleases.ShutDown()
+	}()
+	for s.processNextSlotRelease(ctx) {
+	}
+}
+
+// processNextSlotRelease releases the next queued slot and returns false once the queue is shut down
+func (s *MultiObjectiveScheduler) processNextSlotRelease(ctx context.Context) bool {
+	release, shutdown := s.slotReleases.Get()
+	if shutdown {
+		return false
+	}
+	defer s.slotReleases.Done(release)
+
+	hint := &deschedulerv1alpha1.SchedulingHint{ObjectMeta: metav1.ObjectMeta{Name: release.slot.hintName}}
+	if s.tryReleaseSlot(ctx, hint, release.slot.solutionIndex, release.rsKey, release.slot.nodeName) {
+		s.logger.V(3).Info("Released slot of deleted pod", "pod", release.pod, "slot", release.slot.String())
+	}
+	return true
+}
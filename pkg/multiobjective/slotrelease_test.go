@@ -0,0 +1,306 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package multiobjective
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	"k8s.io/kubernetes/pkg/scheduler/framework"
+	st "k8s.io/kubernetes/pkg/scheduler/testing"
+
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+)
+
+func TestParseConsumedSlot(t *testing.T) {
+	slot := consumedSlot{hintName: "multiobjective-hints-abc", solutionIndex: 2, nodeName: "node-a.example.com"}
+	got, err := parseConsumedSlot(slot.String())
+	if err != nil {
+		t.Fatalf("parseConsumedSlot(%q) unexpected error: %v", slot.String(), err)
+	}
+	if got != slot {
+		t.Errorf("parseConsumedSlot(%q) = %+v, want %+v", slot.String(), got, slot)
+	}
+
+	for _, value := range []string{"", "hint/0", "hint/x/node-a", "hint/-1/node-a", "/0/node-a", "hint/0/"} {
+		if _, err := parseConsumedSlot(value); err == nil {
+			t.Errorf("parseConsumedSlot(%q) expected error, got none", value)
+		}
+	}
+}
+
+func TestReleaseSlotOnPodDelete(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj()}
+	nodeInfos := []*framework.NodeInfo{makeNodeInfo(nodes[0])}
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	kept := st.MakePod().Namespace("default").Name("web-0").SchedulerName(Name).OwnerReference("web", rsOwner).Obj()
+	unreserved := st.MakePod().Namespace("default").Name("web-1").SchedulerName(Name).OwnerReference("web", rsOwner).Obj()
+	running := st.MakePod().Namespace("default").Name("web-2").SchedulerName(Name).OwnerReference("web", rsOwner).Obj()
+
+	s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 3), kept, unreserved, running)
+	hint := createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+		Rank: 1,
+		ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+			{
+				Namespace:          "default",
+				ReplicaSetName:     "web",
+				TargetDistribution: map[string]int{"node-a": 3},
+				AvailableSlots:     map[string]int{"node-a": 3},
+			},
+		},
+	})
+
+	getSlots := func() (int, int) {
+		t.Helper()
+		got, err := s.client.DeschedulerV1alpha1().SchedulingHints().Get(ctx, hint.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get scheduling hint: %v", err)
+		}
+		movement := got.Spec.Solutions[0].ReplicaSetMovements[0]
+		return movement.AvailableSlots["node-a"], movement.ScheduledCount["node-a"]
+	}
+	getAnnotation := func(pod *v1.Pod) (string, bool) {
+		t.Helper()
+		got, err := s.handle.ClientSet().CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("failed to get pod: %v", err)
+		}
+		value, ok := got.Annotations[ConsumedSlotAnnotation]
+		return value, ok
+	}
+	schedule := func(pod *v1.Pod) *framework.CycleState {
+		t.Helper()
+		state := framework.NewCycleState()
+		s.PreFilter(ctx, state, pod)
+		if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+			t.Fatalf("PreScore() unexpected status: %v", status)
+		}
+		if status := s.Reserve(ctx, state, pod, "node-a"); !status.IsSuccess() {
+			t.Fatalf("Reserve() unexpected status: %v", status)
+		}
+		if status := s.PreBind(ctx, state, pod, "node-a"); !status.IsSuccess() {
+			t.Fatalf("PreBind() unexpected status: %v", status)
+		}
+		return state
+	}
+	deletePod := func(pod *v1.Pod) {
+		t.Helper()
+		if err := s.handle.ClientSet().CoreV1().Pods(pod.Namespace).Delete(ctx, pod.Name, metav1.DeleteOptions{}); err != nil {
+			t.Fatalf("failed to delete pod: %v", err)
+		}
+	}
+
+	// All pods consume a slot and are marked with it
+	schedule(kept)
+	unreservedState := schedule(unreserved)
+	schedule(running)
+	if available, scheduled := getSlots(); available != 0 || scheduled != 3 {
+		t.Fatalf("after scheduling: slots = (%d available, %d scheduled), want (0, 3)", available, scheduled)
+	}
+	want := consumedSlot{hintName: hint.Name, solutionIndex: 0, nodeName: "node-a"}.String()
+	if value, _ := getAnnotation(kept); value != want {
+		t.Errorf("pod annotation %s = %q, want %q", ConsumedSlotAnnotation, value, want)
+	}
+
+	// Binding fails for the second pod: its slot is returned once and the pod is unmarked
+	s.Unreserve(ctx, unreservedState, unreserved, "node-a")
+	if _, ok := getAnnotation(unreserved); ok {
+		t.Errorf("pod still marked with consumed slot after Unreserve")
+	}
+	deletePod(unreserved)
+
+	// Deleting the bound pod returns its slot. Deletions are handled in order, so a second release
+	// for the unreserved pod would already be visible once the bound pod's slot is back
+	deletePod(kept)
+	if err := wait.PollUntilContextTimeout(ctx, 10*time.Millisecond, wait.ForeverTestTimeout, true, func(context.Context) (bool, error) {
+		available, _ := getSlots()
+		return available >= 2, nil
+	}); err != nil {
+		t.Fatalf("slot of deleted pod was not released: %v", err)
+	}
+	if available, scheduled := getSlots(); available != 2 || scheduled != 1 {
+		t.Errorf("after delete: slots = (%d available, %d scheduled), want (2, 1)", available, scheduled)
+	}
+}
+
+func TestDeletePodQueuesSlotRelease(t *testing.T) {
+	rsOwner := appsv1.SchemeGroupVersion.WithKind("ReplicaSet")
+	slot := consumedSlot{hintName: "multiobjective-hints-abc", solutionIndex: 0, nodeName: "node-a"}.String()
+
+	tests := []struct {
+		name          string
+		inactive      bool
+		schedulerName string
+		annotations   map[string]string
+		wantQueued    bool
+	}{
+		{
+			name:          "pod of the plugin's profile",
+			schedulerName: Name,
+			annotations:   map[string]string{ConsumedSlotAnnotation: slot},
+			wantQueued:    true,
+		},
+		{
+			name:          "pod without consumed slot",
+			schedulerName: Name,
+		},
+		{
+			name:          "pod of another profile",
+			schedulerName: "default-scheduler",
+			annotations:   map[string]string{ConsumedSlotAnnotation: slot},
+		},
+		{
+			name:          "replica not scheduling yet",
+			inactive:      true,
+			schedulerName: Name,
+			annotations:   map[string]string{ConsumedSlotAnnotation: slot},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &MultiObjectiveScheduler{
+				logger:        klog.Background(),
+				args:          defaultArgs(),
+				schedulerName: Name,
+				alternates:    newAlternateSolutions(),
+				slotReleases:  workqueue.NewTyped[slotRelease](),
+			}
+			s.active.Store(!tt.inactive)
+			pod := st.MakePod().Namespace("default").Name("web-0").SchedulerName(tt.schedulerName).
+				OwnerReference("web", rsOwner).Annotations(tt.annotations).Obj()
+
+			s.deletePod(cache.DeletedFinalStateUnknown{Key: "default/web-0", Obj: pod})
+			if got := s.slotReleases.Len() == 1; got != tt.wantQueued {
+				t.Errorf("deletePod() queued slot release = %v, want %v", got, tt.wantQueued)
+			}
+		})
+	}
+}
+
+func TestPreBindPlacementAnnotations(t *testing.T) {
+	placementKeys := []string{HintNameAnnotation, SolutionRankAnnotation, TargetNodeAnnotation}
+
+	tests := []struct {
+		name     string
+		withHint bool
+		nodeName string
+		want     bool
+	}{
+		{
+			name:     "placed by hint",
+			withHint: true,
+			nodeName: "node-a",
+			want:     true,
+		},
+		{
+			name:     "placed off the hint's target node",
+			withHint: true,
+			nodeName: "node-b",
+		},
+		{
+			name:     "default scoring",
+			nodeName: "node-a",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			nodes := []*v1.Node{st.MakeNode().Name("node-a").Obj(), st.MakeNode().Name("node-b").Obj()}
+			nodeInfos := []*framework.NodeInfo{makeNodeInfo(nodes[0]), makeNodeInfo(nodes[1])}
+			pod := st.MakePod().Namespace("default").Name("web-0").OwnerReference("web", appsv1.SchemeGroupVersion.WithKind("ReplicaSet")).Obj()
+			s := newTestScheduler(ctx, t, defaultArgs(), nodes, makeReplicaSet("default", "web", 2), pod)
+			var hint *deschedulerv1alpha1.SchedulingHint
+			if tt.withHint {
+				hint = createHint(ctx, t, s, deschedulerv1alpha1.OptimizationSolution{
+					Rank: 3,
+					ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+						{
+							Namespace:          "default",
+							ReplicaSetName:     "web",
+							TargetDistribution: map[string]int{"node-a": 2},
+							AvailableSlots:     map[string]int{"node-a": 2},
+						},
+					},
+				})
+			}
+
+			state := framework.NewCycleState()
+			if status := s.PreScore(ctx, state, pod, nodeInfos); !status.IsSuccess() {
+				t.Fatalf("PreScore() unexpected status: %v", status)
+			}
+			if status := s.Reserve(ctx, state, pod, tt.nodeName); !status.IsSuccess() {
+				t.Fatalf("Reserve() unexpected status: %v", status)
+			}
+			if status := s.PreBind(ctx, state, pod, tt.nodeName); !status.IsSuccess() {
+				t.Fatalf("PreBind() unexpected status: %v", status)
+			}
+
+			getAnnotations := func() map[string]string {
+				t.Helper()
+				got, err := s.handle.ClientSet().CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+				if err != nil {
+					t.Fatalf("failed to get pod: %v", err)
+				}
+				return got.Annotations
+			}
+
+			annotations := getAnnotations()
+			if !tt.want {
+				for _, key := range placementKeys {
+					if value, ok := annotations[key]; ok {
+						t.Errorf("pod annotation %s = %q, want none", key, value)
+					}
+				}
+				return
+			}
+			want := map[string]string{
+				HintNameAnnotation:     hint.Name,
+				SolutionRankAnnotation: "3",
+				TargetNodeAnnotation:   "node-a",
+			}
+			for key, value := range want {
+				if annotations[key] != value {
+					t.Errorf("pod annotation %s = %q, want %q", key, annotations[key], value)
+				}
+			}
+
+			// A pod whose binding fails no longer carries the placement
+			s.Unreserve(ctx, state, pod, tt.nodeName)
+			annotations = getAnnotations()
+			for _, key := range placementKeys {
+				if value, ok := annotations[key]; ok {
+					t.Errorf("after Unreserve: pod annotation %s = %q, want none", key, value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"sigs.k8s.io/yaml"
+
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+)
+
+// MarshalSolutions writes the scheduling hint solutions to w as indented JSON, e.g. to capture
+// a hint for reproducing scheduler behavior offline
+func MarshalSolutions(w io.Writer, sols []deschedulerv1alpha1.OptimizationSolution) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(sols); err != nil {
+		return fmt.Errorf("failed to marshal solutions: %w", err)
+	}
+	return nil
+}
+
+// UnmarshalSolutions reads scheduling hint solutions written by MarshalSolutions from r
+func UnmarshalSolutions(r io.Reader) ([]deschedulerv1alpha1.OptimizationSolution, error) {
+	var sols []deschedulerv1alpha1.OptimizationSolution
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&sols); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal solutions: %w", err)
+	}
+	return sols, nil
+}
+
+// MarshalSolutionsYAML writes the scheduling hint solutions to w as YAML
+func MarshalSolutionsYAML(w io.Writer, sols []deschedulerv1alpha1.OptimizationSolution) error {
+	data, err := yaml.Marshal(sols)
+	if err != nil {
+		return fmt.Errorf("failed to marshal solutions: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// UnmarshalSolutionsYAML reads scheduling hint solutions written by MarshalSolutionsYAML from r
+func UnmarshalSolutionsYAML(r io.Reader) ([]deschedulerv1alpha1.OptimizationSolution, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read solutions: %w", err)
+	}
+	var sols []deschedulerv1alpha1.OptimizationSolution
+	if err := yaml.UnmarshalStrict(data, &sols); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal solutions: %w", err)
+	}
+	return sols, nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2024 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	deschedulerv1alpha1 "sigs.k8s.io/scheduler-plugins/apis/descheduler/v1alpha1"
+)
+
+func testSolutions() []deschedulerv1alpha1.OptimizationSolution {
+	return []deschedulerv1alpha1.OptimizationSolution{
+		{
+			Rank:            1,
+			WeightedScore:   0.25,
+			Objectives:      deschedulerv1alpha1.ObjectiveValues{Cost: 0.1, Disruption: 0.2, Balance: 0.3},
+			NamedObjectives: map[string]float64{"power": 0.4},
+			MovementCount:   1,
+			ReplicaSetMovements: []deschedulerv1alpha1.ReplicaSetMovement{
+				{
+					ReplicaSetName:     "web",
+					Namespace:          "default",
+					TargetDistribution: map[string]int{"node-a": 2, "node-b": 1},
+					AvailableSlots:     map[string]int{"node-a": 1, "node-b": 1},
+					ScheduledCount:     map[string]int{"node-a": 1},
+					Reason:             "balance",
+				},
+			},
+		},
+		{
+			Rank:          2,
+			WeightedScore: 0.5,
+		},
+	}
+}
+
+func TestSolutionsRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		marshal   func(io.Writer, []deschedulerv1alpha1.OptimizationSolution) error
+		unmarshal func(io.Reader) ([]deschedulerv1alpha1.OptimizationSolution, error)
+	}{
+		{
+			name:      "json",
+			marshal:   MarshalSolutions,
+			unmarshal: UnmarshalSolutions,
+		},
+		{
+			name:      "yaml",
+			marshal:   MarshalSolutionsYAML,
+			unmarshal: UnmarshalSolutionsYAML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := tt.marshal(&buf, testSolutions()); err != nil {
+				t.Fatalf("marshal unexpected error: %v", err)
+			}
+			got, err := tt.unmarshal(&buf)
+			if err != nil {
+				t.Fatalf("unmarshal unexpected error: %v", err)
+			}
+			if diff := cmp.Diff(testSolutions(), got); diff != "" {
+				t.Errorf("unexpected solutions after round trip (-want, +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnmarshalSolutionsErrors(t *testing.T) {
+	tests := []struct {
+		name      string
+		data      string
+		unmarshal func(io.Reader) ([]deschedulerv1alpha1.OptimizationSolution, error)
+	}{
+		{
+			name:      "malformed json",
+			data:      `[{"rank": 1`,
+			unmarshal: UnmarshalSolutions,
+		},
+		{
+			name:      "unknown json field",
+			data:      `[{"rank": 1, "score": 0.5}]`,
+			unmarshal: UnmarshalSolutions,
+		},
+		{
+			name:      "malformed yaml",
+			data:      "- rank: [1",
+			unmarshal: UnmarshalSolutionsYAML,
+		},
+		{
+			name:      "unknown yaml field",
+			data:      "- rank: 1\n  score: 0.5\n",
+			unmarshal: UnmarshalSolutionsYAML,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.unmarshal(strings.NewReader(tt.data)); err == nil {
+				t.Errorf("unmarshal(%q) expected error, got none", tt.data)
+			}
+		})
+	}
+}